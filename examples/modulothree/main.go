@@ -59,5 +59,11 @@ func main() {
 			log.Fatalln(err.Error())
 		}
 		fmt.Println("Final state output: ", *result)
+
+		remainders, err := fa.Transduce(input)
+		if err != nil {
+			log.Fatalln(err.Error())
+		}
+		fmt.Println("Running remainders: ", remainders)
 	}
 }
@@ -0,0 +1,175 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Function to extend the input symbols with new ones - returns a new automaton
+//   - every missing unguarded transition, on the old and the new symbols, leads to
+//     a non-accepting sink state, which is only added when needed
+//   - the language is unchanged, but the result is complete over the new inputs
+func (fa *FiniteAutomation) ExtendAlphabet(symbols []string) (*FiniteAutomation, error) {
+	if fa == nil || fa.states == nil || fa.initialState == nil {
+		return nil, errors.New("finite Automation has not been initialized")
+	}
+
+	inputs := map[string]bool{}
+	for input := range fa.inputs {
+		inputs[input] = true
+	}
+	for _, symbol := range symbols {
+		inputs[symbol] = true
+	}
+
+	states, copies, transitionFunctions := fa.copyTransitions(func(input string) (string, bool) {
+		return input, true
+	})
+
+	var sink *State
+	sorted := sortedSymbols(inputs)
+	for _, state := range fa.orderedStates() {
+		for _, input := range sorted {
+			if _, ok := state.transition[input]; ok {
+				continue
+			}
+
+			if sink == nil {
+				sink = newState("")
+				states = append(states, sink)
+				for _, symbol := range sorted {
+					tf := TransitionFunction{}
+					tf.Initialize(sink, symbol, sink)
+					transitionFunctions = append(transitionFunctions, tf)
+				}
+			}
+
+			tf := TransitionFunction{}
+			tf.Initialize(copies[state], input, sink)
+			transitionFunctions = append(transitionFunctions, tf)
+		}
+	}
+
+	return newFiniteAutomation(states, inputs, copies[fa.initialState], fa.copyAccepting(copies), transitionFunctions)
+}
+
+// Function to restrict the input symbols to the given ones - returns a new automaton
+//   - transitions on the removed symbols are dropped
+//   - returns an error if a symbol is not an input symbol of the automaton
+func (fa *FiniteAutomation) RestrictAlphabet(symbols []string) (*FiniteAutomation, error) {
+	if fa == nil || fa.states == nil || fa.initialState == nil {
+		return nil, errors.New("finite Automation has not been initialized")
+	}
+
+	inputs := map[string]bool{}
+	for _, symbol := range symbols {
+		if !fa.inputs[symbol] {
+			return nil, errors.New(fmt.Sprintln("Invalid symbol - not in the set of finite inputs: ", symbol))
+		}
+		inputs[symbol] = true
+	}
+
+	states, copies, transitionFunctions := fa.copyTransitions(func(input string) (string, bool) {
+		return input, inputs[input]
+	})
+
+	return newFiniteAutomation(states, inputs, copies[fa.initialState], fa.copyAccepting(copies), transitionFunctions)
+}
+
+// Function to rename input symbols consistently across all transitions - returns a
+// new automaton
+//   - symbols missing from renames keep their name
+//   - returns an error if a renamed symbol is not an input symbol, or if two
+//     symbols would end up with the same name
+func (fa *FiniteAutomation) RenameSymbols(renames map[string]string) (*FiniteAutomation, error) {
+	if fa == nil || fa.states == nil || fa.initialState == nil {
+		return nil, errors.New("finite Automation has not been initialized")
+	}
+
+	for symbol := range renames {
+		if !fa.inputs[symbol] {
+			return nil, errors.New(fmt.Sprintln("Invalid symbol - not in the set of finite inputs: ", symbol))
+		}
+	}
+
+	rename := func(input string) (string, bool) {
+		if renamed, ok := renames[input]; ok {
+			return renamed, true
+		}
+
+		return input, true
+	}
+
+	inputs := map[string]bool{}
+	for _, input := range fa.sortedInputs() {
+		renamed, _ := rename(input)
+		if inputs[renamed] {
+			return nil, errors.New(fmt.Sprintln("Invalid rename - two symbols renamed to: ", renamed))
+		}
+		inputs[renamed] = true
+	}
+
+	states, copies, transitionFunctions := fa.copyTransitions(rename)
+
+	return newFiniteAutomation(states, inputs, copies[fa.initialState], fa.copyAccepting(copies), transitionFunctions)
+}
+
+// Function to extend both automata to the union of their input symbols, as
+// required by product constructions and equivalence checks
+func AlignAlphabets(a *FiniteAutomation, b *FiniteAutomation) (*FiniteAutomation, *FiniteAutomation, error) {
+	if a == nil || b == nil {
+		return nil, nil, errors.New("finite Automation has not been initialized")
+	}
+
+	alignedA, err := a.ExtendAlphabet(b.sortedInputs())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	alignedB, err := b.ExtendAlphabet(a.sortedInputs())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return alignedA, alignedB, nil
+}
+
+// copyTransitions copies the states and the transitions of the automaton, with
+// the input of every transition mapped by mapInput, transitions it rejects are dropped
+//   - outputs and guards are kept
+func (fa *FiniteAutomation) copyTransitions(mapInput func(input string) (string, bool)) ([]*State, map[*State]*State, []TransitionFunction) {
+	copies := map[*State]*State{}
+	states := []*State{}
+	for _, state := range fa.orderedStates() {
+		copies[state] = newState(state.output)
+		states = append(states, copies[state])
+	}
+
+	transitionFunctions := []TransitionFunction{}
+	for _, transitionFunction := range fa.transitionFunctions {
+		input, ok := mapInput(transitionFunction.input)
+		if !ok {
+			continue
+		}
+
+		tf := TransitionFunction{}
+		tf.Initialize(copies[transitionFunction.currentState], input, copies[transitionFunction.transitionState])
+		tf.SetOutput(transitionFunction.output)
+		tf.SetGuard(transitionFunction.guard)
+		transitionFunctions = append(transitionFunctions, tf)
+	}
+
+	return states, copies, transitionFunctions
+}
+
+// copyAccepting returns the copies of the accepting states
+func (fa *FiniteAutomation) copyAccepting(copies map[*State]*State) []*State {
+	acceptingStates := []*State{}
+	for _, state := range fa.orderedStates() {
+		if fa.acceptingStates[state] {
+			acceptingStates = append(acceptingStates, copies[state])
+		}
+	}
+
+	return acceptingStates
+}
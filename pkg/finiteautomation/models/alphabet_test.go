@@ -0,0 +1,98 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestExtendAlphabet_NoError tests that new symbols lead to a sink and the
+// language is unchanged.
+func TestExtendAlphabet_NoError(t *testing.T) {
+	fa := GetMockFiniteAutomation()
+
+	extended, err := fa.ExtendAlphabet([]string{"2"})
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	if len(extended.states) != 3 || len(extended.transitionFunctions) != 9 {
+		t.Errorf("Expected 3 states and 9 transitions, got %d and %d", len(extended.states), len(extended.transitionFunctions))
+	}
+
+	for _, input := range append(binaryStrings(6), "012", "2") {
+		_, expected := fa.Compute(input)
+		_, err := extended.Compute(input)
+
+		if (err == nil) != (expected == nil) {
+			t.Errorf("Expected %t for input %s", expected == nil, input)
+		}
+	}
+
+	_, err = extended.Compute("2")
+	if err == nil || !strings.Contains(err.Error(), "Invalid final state") {
+		t.Errorf("Expected invalid final state for input 2, got %v", err)
+	}
+}
+
+// TestRestrictAlphabet_NoError tests that transitions on removed symbols are dropped.
+func TestRestrictAlphabet_NoError(t *testing.T) {
+	fa := GetMockModuloThreeFiniteAutomation()
+
+	restricted, err := fa.RestrictAlphabet([]string{"1"})
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	if _, err := restricted.Compute("111"); err != nil {
+		t.Errorf("Expected nil error, got %v", err)
+	}
+
+	if _, err := restricted.Compute("10"); err == nil {
+		t.Errorf("Expected error for invalid input, got nil")
+	}
+
+	if _, err := fa.RestrictAlphabet([]string{"2"}); err == nil {
+		t.Errorf("Expected error for unknown symbol, got nil")
+	}
+}
+
+// TestRenameSymbols_NoError tests that symbols are renamed in every transition.
+func TestRenameSymbols_NoError(t *testing.T) {
+	fa := GetMockModuloThreeFiniteAutomation()
+
+	renamed, err := fa.RenameSymbols(map[string]string{"0": "a", "1": "b"})
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	result, err := renamed.Compute("babba") // 10110 = 22
+	if err != nil || *result != "1" {
+		t.Errorf("Expected %s, got %v %v", "1", result, err)
+	}
+}
+
+// TestRenameSymbols_ErrorCollision tests that two symbols cannot get the same name.
+func TestRenameSymbols_ErrorCollision(t *testing.T) {
+	fa := GetMockModuloThreeFiniteAutomation()
+
+	_, err := fa.RenameSymbols(map[string]string{"0": "1"})
+
+	if err == nil {
+		t.Errorf("Expected error for colliding names, got nil")
+	}
+}
+
+// TestAlignAlphabets_NoError tests that both automata end up with the same inputs.
+func TestAlignAlphabets_NoError(t *testing.T) {
+	a := GetMockFiniteAutomation()
+	b := GetMockOnesFiniteAutomation()
+
+	alignedA, alignedB, err := AlignAlphabets(&a, &b)
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	if len(alignedA.inputs) != 3 || len(alignedB.inputs) != 3 || !alignedA.inputs["x"] {
+		t.Errorf("Expected inputs {0, 1, x}, got %v and %v", alignedA.inputs, alignedB.inputs)
+	}
+}
@@ -0,0 +1,399 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Automaton defines a generic deterministic finite automaton.
+// It includes:
+//   - inputs: the valid input symbols, of any comparable type S.
+//   - outputs: the output of every state, of any type O, indexed by state id.
+//   - transitions: for every state id, a mapping of input symbols to the next state id.
+//   - transitionOutputs: for every state id, the output of the transitions that
+//     have one (Mealy output), nil until one is added.
+//   - guarded: for every state id, the guarded transitions of every input symbol
+//     in declaration order, nil until one is added.
+//   - accepting: for every state id, whether the state is an accepting state.
+//   - initialState: the id of the starting state.
+//
+// FiniteAutomation is the string-based form of the same model: it wraps an
+// Automaton[string, string] and runs its inputs through it, see ToAutomaton and
+// FromAutomaton.
+type Automaton[S comparable, O any] struct {
+	inputs            map[S]bool
+	outputs           []O
+	transitions       []map[S]int
+	transitionOutputs []map[S]O
+	guarded           []map[S][]guardedTransition[O]
+	accepting         []bool
+	initialState      int
+}
+
+// guardedTransition is a transition taken only when its guard holds for the context
+type guardedTransition[O any] struct {
+	guard  func(ctx Context) bool
+	to     int
+	output O
+}
+
+// Function to create an Automaton over the given input symbols
+//   - the automaton has no states yet, the first state added becomes the initial state
+func NewAutomaton[S comparable, O any](inputs []S) *Automaton[S, O] {
+	a := &Automaton[S, O]{inputs: map[S]bool{}}
+	for _, input := range inputs {
+		a.inputs[input] = true
+	}
+
+	return a
+}
+
+// Function to add a state - returns the id of the new state
+func (a *Automaton[S, O]) AddState(output O, accepting bool) int {
+	a.outputs = append(a.outputs, output)
+	a.transitions = append(a.transitions, map[S]int{})
+	a.transitionOutputs = append(a.transitionOutputs, nil)
+	a.guarded = append(a.guarded, nil)
+	a.accepting = append(a.accepting, accepting)
+
+	return len(a.outputs) - 1
+}
+
+// Function to add or update the transition from one state to another on an input
+//   - both states must exist and the input must be in the set of inputs
+//   - an existing transition on the input is overwritten, along with its output
+func (a *Automaton[S, O]) AddTransition(from int, input S, to int) error {
+	if err := a.checkTransition(from, input, to); err != nil {
+		return err
+	}
+
+	a.transitions[from][input] = to
+	delete(a.transitionOutputs[from], input)
+
+	return nil
+}
+
+// Function to add or update a transition that emits an output (Mealy output)
+//   - same checks as AddTransition
+func (a *Automaton[S, O]) AddTransitionWithOutput(from int, input S, to int, output O) error {
+	if err := a.checkTransition(from, input, to); err != nil {
+		return err
+	}
+
+	a.transitions[from][input] = to
+	if a.transitionOutputs[from] == nil {
+		a.transitionOutputs[from] = map[S]O{}
+	}
+	a.transitionOutputs[from][input] = output
+
+	return nil
+}
+
+// Function to add a transition taken only when the guard holds for the context
+//   - same checks as AddTransition, and the guard must not be nil
+//   - guarded transitions on the same input are evaluated in declaration order
+//     before the unguarded one, like in FiniteAutomation
+func (a *Automaton[S, O]) AddGuardedTransition(from int, input S, to int, guard func(ctx Context) bool, output O) error {
+	if err := a.checkTransition(from, input, to); err != nil {
+		return err
+	}
+
+	if guard == nil {
+		return errors.New(fmt.Sprintln("Transition Function invalid - Guard is nil on input", input))
+	}
+
+	if a.guarded[from] == nil {
+		a.guarded[from] = map[S][]guardedTransition[O]{}
+	}
+	a.guarded[from][input] = append(a.guarded[from][input], guardedTransition[O]{guard: guard, to: to, output: output})
+
+	return nil
+}
+
+// Function to set the initial state
+func (a *Automaton[S, O]) SetInitialState(state int) error {
+	if !a.isState(state) {
+		return errors.New(fmt.Sprintln("Initial State invalid - Initial state not in the set of states: ", state))
+	}
+
+	a.initialState = state
+
+	return nil
+}
+
+func (a *Automaton[S, O]) GetOutput(state int) O {
+	return a.outputs[state]
+}
+
+func (a *Automaton[S, O]) IsAccepting(state int) bool {
+	return a.accepting[state]
+}
+
+// Function to compute the final state - returns the output of the final state
+//   - same errors as FiniteAutomation.Compute
+func (a *Automaton[S, O]) Compute(input []S) (O, error) {
+	return a.ComputeWithContext(input, nil)
+}
+
+// Function to compute the final state with runtime data for guarded transitions
+//   - same errors as FiniteAutomation.Compute
+func (a *Automaton[S, O]) ComputeWithContext(input []S, ctx Context) (O, error) {
+	var result O
+
+	state, err := a.run(input, ctx, nil)
+	if err != nil {
+		return result, err
+	}
+
+	return a.outputs[state], nil
+}
+
+// Function to compute the Moore output sequence - returns the output of every
+// visited state, starting with the initial state
+//   - same errors as FiniteAutomation.Compute
+func (a *Automaton[S, O]) Transduce(input []S) ([]O, error) {
+	return a.TransduceWithContext(input, nil)
+}
+
+// Function to compute the Moore output sequence with runtime data for guarded
+// transitions
+//   - same errors as FiniteAutomation.Compute
+func (a *Automaton[S, O]) TransduceWithContext(input []S, ctx Context) ([]O, error) {
+	if a == nil || len(a.outputs) == 0 {
+		return nil, errors.New("automaton has not been initialized")
+	}
+
+	outputs := []O{a.outputs[a.initialState]}
+
+	_, err := a.run(input, ctx, func(transitionOutput O, state int) {
+		outputs = append(outputs, a.outputs[state])
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return outputs, nil
+}
+
+// Function to compute the Mealy output sequence - returns the output of every
+// transition taken, the zero value for transitions without output
+//   - same errors as FiniteAutomation.Compute
+func (a *Automaton[S, O]) Translate(input []S) ([]O, error) {
+	return a.TranslateWithContext(input, nil)
+}
+
+// Function to compute the Mealy output sequence with runtime data for guarded
+// transitions
+//   - same errors as FiniteAutomation.Compute
+func (a *Automaton[S, O]) TranslateWithContext(input []S, ctx Context) ([]O, error) {
+	outputs := make([]O, 0, len(input))
+
+	_, err := a.run(input, ctx, func(transitionOutput O, state int) {
+		outputs = append(outputs, transitionOutput)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return outputs, nil
+}
+
+// run feeds the input through the automaton and returns the accepting state it ends in
+//   - visit, when not nil, is called with the output of every transition taken and
+//     the state it leads to
+func (a *Automaton[S, O]) run(input []S, ctx Context, visit func(transitionOutput O, state int)) (int, error) {
+	if a == nil || len(a.outputs) == 0 {
+		return 0, errors.New("automaton has not been initialized")
+	}
+
+	state := a.initialState
+	for _, symbol := range input {
+		if !a.inputs[symbol] {
+			return 0, errors.New(fmt.Sprintln("Invalid input: ", symbol))
+		}
+
+		next, transitionOutput, ok := a.nextState(state, symbol, ctx)
+		if !ok {
+			return 0, errors.New(fmt.Sprintln("Invalid transition: ", symbol))
+		}
+
+		state = next
+		if visit != nil {
+			visit(transitionOutput, state)
+		}
+	}
+
+	if !a.accepting[state] {
+		return 0, errors.New(fmt.Sprintln("Invalid final state - not in the list of accepting state -", a.outputs[state]))
+	}
+
+	return state, nil
+}
+
+// nextState returns the state reached on the input and the output of the
+// transition taken, the first guard holding wins over the unguarded transition
+func (a *Automaton[S, O]) nextState(state int, symbol S, ctx Context) (int, O, bool) {
+	for _, transition := range a.guarded[state][symbol] {
+		if transition.guard(ctx) {
+			return transition.to, transition.output, true
+		}
+	}
+
+	next, ok := a.transitions[state][symbol]
+
+	return next, a.transitionOutputs[state][symbol], ok
+}
+
+// checkTransition checks that both states exist and the input is in the set of inputs
+func (a *Automaton[S, O]) checkTransition(from int, input S, to int) error {
+	if !a.isState(from) || !a.isState(to) {
+		return errors.New(fmt.Sprintln("Transition Function invalid - State not in the set of states: ", from, to))
+	}
+
+	if !a.inputs[input] {
+		return errors.New(fmt.Sprintln("Transition Function invalid - Input not in the set of finite inputs", input))
+	}
+
+	return nil
+}
+
+// clone returns a copy of the automaton sharing no maps with it
+func (a *Automaton[S, O]) clone() *Automaton[S, O] {
+	c := &Automaton[S, O]{
+		inputs:            make(map[S]bool, len(a.inputs)),
+		outputs:           append([]O{}, a.outputs...),
+		transitions:       make([]map[S]int, len(a.transitions)),
+		transitionOutputs: make([]map[S]O, len(a.transitionOutputs)),
+		guarded:           make([]map[S][]guardedTransition[O], len(a.guarded)),
+		accepting:         append([]bool{}, a.accepting...),
+		initialState:      a.initialState,
+	}
+
+	for input := range a.inputs {
+		c.inputs[input] = true
+	}
+
+	for state := range a.outputs {
+		c.transitions[state] = make(map[S]int, len(a.transitions[state]))
+		for input, next := range a.transitions[state] {
+			c.transitions[state][input] = next
+		}
+
+		if a.transitionOutputs[state] != nil {
+			c.transitionOutputs[state] = make(map[S]O, len(a.transitionOutputs[state]))
+			for input, output := range a.transitionOutputs[state] {
+				c.transitionOutputs[state][input] = output
+			}
+		}
+
+		if a.guarded[state] != nil {
+			c.guarded[state] = make(map[S][]guardedTransition[O], len(a.guarded[state]))
+			for input, transitions := range a.guarded[state] {
+				c.guarded[state][input] = append([]guardedTransition[O]{}, transitions...)
+			}
+		}
+	}
+
+	return c
+}
+
+func (a *Automaton[S, O]) isState(state int) bool {
+	return state >= 0 && state < len(a.outputs)
+}
+
+// Function to convert the FiniteAutomation into its generic form - returns a
+// copy of the automaton it wraps
+//   - state ids follow the breadth-first order from the initial state, which gets
+//     id 0, unreachable states come last
+//   - guarded transitions and transition outputs (Mealy machines) are kept
+func (fa *FiniteAutomation) ToAutomaton() (*Automaton[string, string], error) {
+	if fa == nil || fa.core == nil {
+		return nil, errors.New("finite Automation has not been initialized")
+	}
+
+	return fa.core.clone(), nil
+}
+
+// Function to convert a generic automaton over string symbols back into a
+// FiniteAutomation, so the algorithms of FiniteAutomation (validation, export,
+// compilation, equivalence, ...) can be used on it
+//   - guarded transitions and transition outputs are kept, an empty transition
+//     output is the same as none
+//   - returns an error if the automaton has no states
+func FromAutomaton(a *Automaton[string, string]) (*FiniteAutomation, error) {
+	if a == nil || len(a.outputs) == 0 {
+		return nil, errors.New("automaton has not been initialized")
+	}
+
+	states := make([]*State, len(a.outputs))
+	acceptingStates := []*State{}
+	for id, output := range a.outputs {
+		states[id] = newState(output)
+		if a.accepting[id] {
+			acceptingStates = append(acceptingStates, states[id])
+		}
+	}
+
+	inputs := make([]string, 0, len(a.inputs))
+	for input := range a.inputs {
+		inputs = append(inputs, input)
+	}
+	sort.Strings(inputs)
+
+	transitionFunctions := []TransitionFunction{}
+	for id := range a.outputs {
+		for _, input := range inputs {
+			for _, transition := range a.guarded[id][input] {
+				tf := TransitionFunction{}
+				tf.Initialize(states[id], input, states[transition.to])
+				tf.SetGuard(transition.guard)
+				tf.SetOutput(transition.output)
+				transitionFunctions = append(transitionFunctions, tf)
+			}
+
+			if next, ok := a.transitions[id][input]; ok {
+				tf := TransitionFunction{}
+				tf.Initialize(states[id], input, states[next])
+				tf.SetOutput(a.transitionOutputs[id][input])
+				transitionFunctions = append(transitionFunctions, tf)
+			}
+		}
+	}
+
+	inputSet := make(map[string]bool, len(inputs))
+	for _, input := range inputs {
+		inputSet[input] = true
+	}
+
+	return newFiniteAutomation(states, inputSet, states[a.initialState], acceptingStates, transitionFunctions)
+}
+
+// buildCore builds the generic automaton FiniteAutomation runs its inputs through
+//   - returns the automaton and the state of every id
+func (fa *FiniteAutomation) buildCore() (*Automaton[string, string], []*State) {
+	states := fa.orderedStates()
+	a := NewAutomaton[string, string](fa.sortedInputs())
+	ids := make(map[*State]int, len(states))
+	for _, state := range states {
+		ids[state] = a.AddState(state.output, fa.acceptingStates[state])
+	}
+
+	for _, state := range states {
+		for input, transitionFunctions := range state.guardedTransitions {
+			for _, transitionFunction := range transitionFunctions {
+				a.AddGuardedTransition(ids[state], input, ids[transitionFunction.transitionState], transitionFunction.guard, transitionFunction.output)
+			}
+		}
+
+		for input, next := range state.transition {
+			if output, ok := state.transitionOutput[input]; ok {
+				a.AddTransitionWithOutput(ids[state], input, ids[next], output)
+			} else {
+				a.AddTransition(ids[state], input, ids[next])
+			}
+		}
+	}
+
+	return a, states
+}
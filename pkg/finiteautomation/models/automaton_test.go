@@ -0,0 +1,189 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+type bit byte
+
+const (
+	zero bit = iota
+	one
+)
+
+// GetMockModuloThreeAutomation returns the modulo-three automaton over typed
+// bits with the remainder as int output.
+func GetMockModuloThreeAutomation() *Automaton[bit, int] {
+	a := NewAutomaton[bit, int]([]bit{zero, one})
+	for remainder := 0; remainder < 3; remainder++ {
+		a.AddState(remainder, true)
+	}
+
+	for remainder := 0; remainder < 3; remainder++ {
+		a.AddTransition(remainder, zero, (remainder*2)%3)
+		a.AddTransition(remainder, one, (remainder*2+1)%3)
+	}
+
+	return a
+}
+
+// TestAutomaton_Compute tests that the generic automaton computes a typed output.
+func TestAutomaton_Compute(t *testing.T) {
+	a := GetMockModuloThreeAutomation()
+
+	result, err := a.Compute([]bit{one, zero, one, one, zero}) // 22
+
+	if err != nil {
+		t.Errorf("Expected nil error, got %v", err)
+	}
+
+	if result != 1 {
+		t.Errorf("Expected %d, got %d", 1, result)
+	}
+}
+
+// TestAutomaton_Transduce tests that the generic automaton returns the output of
+// every visited state.
+func TestAutomaton_Transduce(t *testing.T) {
+	a := GetMockModuloThreeAutomation()
+
+	result, err := a.Transduce([]bit{one, zero, zero, one})
+
+	if err != nil {
+		t.Errorf("Expected nil error, got %v", err)
+	}
+
+	if !reflect.DeepEqual(result, []int{0, 1, 2, 1, 0}) {
+		t.Errorf("Expected %v, got %v", []int{0, 1, 2, 1, 0}, result)
+	}
+}
+
+// TestAutomaton_ErrorInvalidInput tests that symbols outside the inputs are rejected.
+func TestAutomaton_ErrorInvalidInput(t *testing.T) {
+	a := GetMockModuloThreeAutomation()
+
+	_, err := a.Compute([]bit{one, 2})
+
+	if err == nil {
+		t.Errorf("Expected error for invalid input, got nil")
+	}
+}
+
+// TestAutomaton_ErrorInvalidTransition tests that AddTransition rejects unknown states.
+func TestAutomaton_ErrorInvalidTransition(t *testing.T) {
+	a := GetMockModuloThreeAutomation()
+
+	err := a.AddTransition(0, zero, 3)
+
+	if err == nil {
+		t.Errorf("Expected error for invalid transition, got nil")
+	}
+}
+
+// TestToAutomaton_NoError tests that converting a FiniteAutomation keeps its results.
+func TestToAutomaton_NoError(t *testing.T) {
+	fa := GetMockModuloThreeFiniteAutomation()
+
+	a, err := fa.ToAutomaton()
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	result, err := a.Compute([]string{"1", "0", "1", "1", "0"})
+
+	if err != nil {
+		t.Errorf("Expected nil error, got %v", err)
+	}
+
+	if result != "1" {
+		t.Errorf("Expected %s, got %s", "1", result)
+	}
+}
+
+// TestToAutomaton_TransitionOutput tests that Mealy transition outputs are kept.
+func TestToAutomaton_TransitionOutput(t *testing.T) {
+	fa := GetMockMealyFiniteAutomation()
+
+	a, err := fa.ToAutomaton()
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	result, err := a.Translate([]string{"0", "0", "1", "1", "0"})
+
+	if err != nil {
+		t.Errorf("Expected nil error, got %v", err)
+	}
+
+	expected := []string{"e", "c", "t", "c", "t"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+// TestAutomaton_GuardedTransition tests that guards are evaluated in declaration
+// order before the unguarded transition.
+func TestAutomaton_GuardedTransition(t *testing.T) {
+	a := NewAutomaton[string, string]([]string{"P"})
+	pending := a.AddState("Pending", false)
+	partial := a.AddState("PartiallyPaid", true)
+	paid := a.AddState("Paid", true)
+	a.AddGuardedTransition(pending, "P", paid, func(ctx Context) bool {
+		return ctx["amount"].(int) >= ctx["total"].(int)
+	}, "")
+	a.AddTransition(pending, "P", partial)
+
+	for amount, expected := range map[int]string{100: "Paid", 40: "PartiallyPaid"} {
+		result, err := a.ComputeWithContext([]string{"P"}, Context{"amount": amount, "total": 100})
+
+		if err != nil {
+			t.Errorf("Expected nil error, got %v", err)
+		}
+
+		if result != expected {
+			t.Errorf("Expected %s, got %s", expected, result)
+		}
+	}
+
+	if err := a.AddGuardedTransition(pending, "P", paid, nil, ""); err == nil {
+		t.Errorf("Expected error for nil guard, got nil")
+	}
+}
+
+// TestFromAutomaton_NoError tests that a generic automaton converted back into a
+// FiniteAutomation keeps its results, transition outputs and guards.
+func TestFromAutomaton_NoError(t *testing.T) {
+	mealy := GetMockMealyFiniteAutomation()
+	a, _ := mealy.ToAutomaton()
+
+	fa, err := FromAutomaton(a)
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	result, err := fa.Translate("00110")
+
+	if err != nil || *result != "ectct" {
+		t.Errorf("Expected %s, got %v", "ectct", err)
+	}
+
+	guarded := GetMockGuardedFiniteAutomation()
+	a, _ = guarded.ToAutomaton()
+	fa, _ = FromAutomaton(a)
+
+	result, err = fa.ComputeWithContext("P", Context{"amount": 100, "total": 100})
+
+	if err != nil || *result != "Paid" {
+		t.Errorf("Expected %s, got %v", "Paid", err)
+	}
+}
+
+// TestFromAutomaton_ErrorNotInitialized tests that an automaton without states is rejected.
+func TestFromAutomaton_ErrorNotInitialized(t *testing.T) {
+	_, err := FromAutomaton(NewAutomaton[string, string]([]string{"0"}))
+
+	if err == nil {
+		t.Errorf("Expected error for an automaton without states, got nil")
+	}
+}
@@ -0,0 +1,247 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// CompiledDFA is a table-driven form of a FiniteAutomation for fast matching.
+// It contains:
+//   - symbols: dense ids of the single-rune input symbols, ASCII runes are looked
+//     up in asciiSymbols without hashing.
+//   - table: a flat transition table, table[state*numSymbols+symbol] is the next
+//     state or -1 when the transition is undefined.
+//   - byteClasses: the equivalence class of every byte, bytes in the same class
+//     lead to the same state from every state.
+//   - byteTable: a flat transition table over byte classes, used by MatchBytes.
+//     Its rows are the states followed by the states reached inside multi-byte
+//     UTF-8 symbols, which are never accepting.
+//   - numByteStates: the number of rows of byteTable.
+//   - accepting: a bitset of the accepting states.
+//   - outputs: the output of every state.
+type CompiledDFA struct {
+	numSymbols     int32
+	asciiSymbols   [utf8.RuneSelf]int32
+	symbols        map[rune]int32
+	table          []int32
+	numByteClasses int32
+	byteClasses    [256]uint8
+	byteTable      []int32
+	numByteStates  int32
+	accepting      []uint64
+	outputs        []string
+	initialState   int32
+}
+
+// Function to compile the FiniteAutomation into a CompiledDFA
+//   - states get dense ids in breadth-first order from the initial state, which gets id 0
+//   - only single-rune input symbols can be matched, like in Compute
+//   - returns nil if the automaton is not initialized, uses guarded transitions or
+//     has an input symbol that is not a single rune
+func (fa *FiniteAutomation) Compile() *CompiledDFA {
+	if fa.checkUnguarded() != nil {
+		return nil
+	}
+
+	c := &CompiledDFA{symbols: map[rune]int32{}}
+	for i := range c.asciiSymbols {
+		c.asciiSymbols[i] = -1
+	}
+
+	for _, input := range fa.sortedInputs() {
+		char, size := utf8.DecodeRuneInString(input)
+		if size == 0 || size != len(input) || (char == utf8.RuneError && size == 1) {
+			return nil
+		}
+
+		if char < utf8.RuneSelf {
+			c.asciiSymbols[char] = c.numSymbols
+		}
+		c.symbols[char] = c.numSymbols
+		c.numSymbols++
+	}
+
+	states := fa.orderedStates()
+	ids := make(map[*State]int32, len(states))
+	for i, state := range states {
+		ids[state] = int32(i)
+	}
+
+	c.table = make([]int32, len(states)*int(c.numSymbols))
+	c.accepting = make([]uint64, (len(states)+63)/64)
+	c.outputs = make([]string, len(states))
+	for i, state := range states {
+		c.outputs[i] = state.output
+		if fa.acceptingStates[state] {
+			c.accepting[i/64] |= 1 << (uint(i) % 64)
+		}
+
+		row := c.table[i*int(c.numSymbols) : (i+1)*int(c.numSymbols)]
+		for j := range row {
+			row[j] = -1
+		}
+
+		for input, next := range state.transition {
+			char, _ := utf8.DecodeRuneInString(input)
+			row[c.symbols[char]] = ids[next]
+		}
+	}
+
+	c.compileBytes(states, ids)
+
+	return c
+}
+
+// compileBytes builds the byte-level table
+//   - an input symbol is read as the bytes of its UTF-8 encoding, the bytes before
+//     the last one of a multi-byte symbol lead to intermediate rows, shared by the
+//     symbols of a state with the same leading bytes
+//   - bytes whose column (next row from every row) is identical share a class,
+//     bytes that start no symbol all fall into the same dead class
+func (c *CompiledDFA) compileBytes(states []*State, ids map[*State]int32) {
+	rows := make([]map[byte]int32, len(states))
+	for i, state := range states {
+		for input, next := range state.transition {
+			row := int32(i)
+			for k := 0; k < len(input)-1; k++ {
+				if rows[row] == nil {
+					rows[row] = map[byte]int32{}
+				}
+
+				inner, ok := rows[row][input[k]]
+				if !ok {
+					inner = int32(len(rows))
+					rows = append(rows, nil)
+					rows[row][input[k]] = inner
+				}
+				row = inner
+			}
+
+			if rows[row] == nil {
+				rows[row] = map[byte]int32{}
+			}
+			rows[row][input[len(input)-1]] = ids[next]
+		}
+	}
+
+	c.numByteStates = int32(len(rows))
+	if words := (len(rows) + 63) / 64; words > len(c.accepting) {
+		c.accepting = append(c.accepting, make([]uint64, words-len(c.accepting))...)
+	}
+
+	columns := make([][]int32, 256)
+	classes := map[string]uint8{}
+	for b := 0; b < 256; b++ {
+		column := make([]int32, len(rows))
+		var key strings.Builder
+		for row := range rows {
+			column[row] = -1
+			if next, ok := rows[row][byte(b)]; ok {
+				column[row] = next
+			}
+
+			key.WriteString(strconv.Itoa(int(column[row])))
+			key.WriteByte(',')
+		}
+
+		class, ok := classes[key.String()]
+		if !ok {
+			class = uint8(len(classes))
+			classes[key.String()] = class
+			columns[class] = column
+		}
+		c.byteClasses[b] = class
+	}
+
+	c.numByteClasses = int32(len(classes))
+	c.byteTable = make([]int32, len(rows)*len(classes))
+	for class := 0; class < len(classes); class++ {
+		for row, next := range columns[class] {
+			c.byteTable[row*len(classes)+class] = next
+		}
+	}
+}
+
+// Function to check if the input is accepted
+//   - returns false for undefined inputs or transitions, like Compute returns an error
+func (c *CompiledDFA) Match(input string) bool {
+	state, ok := c.Run(input)
+
+	return ok && c.IsAccepting(state)
+}
+
+// Function to run the input through the table - returns the id of the final state
+//   - ok is false if an input or a transition is undefined
+func (c *CompiledDFA) Run(input string) (state int32, ok bool) {
+	state = c.initialState
+	for _, char := range input {
+		var symbol int32
+		if char < utf8.RuneSelf {
+			symbol = c.asciiSymbols[char]
+		} else if id, found := c.symbols[char]; found {
+			symbol = id
+		} else {
+			symbol = -1
+		}
+
+		if symbol < 0 {
+			return state, false
+		}
+
+		state = c.table[state*c.numSymbols+symbol]
+		if state < 0 {
+			return state, false
+		}
+	}
+
+	return state, true
+}
+
+// Function to check if the raw bytes are accepted
+//   - every input symbol is read as the bytes of its UTF-8 encoding, so the result
+//     is the same as Match on valid UTF-8, invalid bytes are never matched
+func (c *CompiledDFA) MatchBytes(input []byte) bool {
+	state, ok := c.RunBytes(input)
+
+	return ok && c.IsAccepting(state)
+}
+
+// Function to run the raw bytes through the byte-level table - returns the id of the final state
+//   - ok is false if an input or a transition is undefined, or if the input ends
+//     inside a multi-byte symbol
+func (c *CompiledDFA) RunBytes(input []byte) (state int32, ok bool) {
+	state, ok = c.runBytes(c.initialState, input)
+
+	return state, ok && state < int32(len(c.outputs))
+}
+
+// runBytes runs the bytes from the given row of the byte-level table - returns
+// the row it ends in, which may be inside a multi-byte symbol
+//   - ok is false if a transition is undefined
+func (c *CompiledDFA) runBytes(state int32, input []byte) (int32, bool) {
+	for _, b := range input {
+		state = c.byteTable[state*c.numByteClasses+int32(c.byteClasses[b])]
+		if state < 0 {
+			return state, false
+		}
+	}
+
+	return state, true
+}
+
+func (c *CompiledDFA) NumByteClasses() int {
+	return int(c.numByteClasses)
+}
+
+func (c *CompiledDFA) IsAccepting(state int32) bool {
+	return c.accepting[state/64]&(1<<(uint(state)%64)) != 0
+}
+
+func (c *CompiledDFA) GetOutput(state int32) string {
+	return c.outputs[state]
+}
+
+func (c *CompiledDFA) NumStates() int {
+	return len(c.outputs)
+}
@@ -0,0 +1,140 @@
+package models
+
+import "sync"
+
+// minParallelChunk is the smallest chunk worth handing to a worker, shorter
+// inputs are matched on a single goroutine
+const minParallelChunk = 64 * 1024
+
+// Function to check if the raw bytes are accepted, using several workers
+//   - the input is split into one chunk per worker
+//   - the first chunk runs from the initial state, every other chunk runs from
+//     every state at once and records the state each start ends in
+//   - the per-chunk state maps are stitched together in order
+//   - returns the same result as MatchBytes
+func (c *CompiledDFA) MatchParallel(input []byte, workers int) bool {
+	if workers > len(input)/minParallelChunk {
+		workers = len(input) / minParallelChunk
+	}
+
+	if workers <= 1 {
+		return c.MatchBytes(input)
+	}
+
+	chunkSize := (len(input) + workers - 1) / workers
+	stateMaps := make([][]int32, workers)
+	var first int32
+	var firstOk bool
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		start := i * chunkSize
+		end := start + chunkSize
+		if end > len(input) {
+			end = len(input)
+		}
+
+		wg.Add(1)
+		go func(i int, chunk []byte) {
+			defer wg.Done()
+			if i == 0 {
+				first, firstOk = c.runBytes(c.initialState, chunk)
+				return
+			}
+
+			stateMaps[i] = c.runSpeculative(chunk)
+		}(i, input[start:end])
+	}
+	wg.Wait()
+
+	if !firstOk {
+		return false
+	}
+
+	state := first
+	for i := 1; i < workers; i++ {
+		state = stateMaps[i][state]
+		if state < 0 {
+			return false
+		}
+	}
+
+	return c.IsAccepting(state)
+}
+
+// runSpeculative runs the chunk from every row of the byte-level table, since a
+// chunk may start inside a multi-byte symbol - returns for every start row the
+// row the chunk ends in, or -1 if a transition is undefined
+//   - runs that reach the same state are merged, so the work per byte shrinks to
+//     the number of distinct states still alive
+func (c *CompiledDFA) runSpeculative(chunk []byte) []int32 {
+	n := int(c.numByteStates)
+	slotOf := make([]int32, n)
+	current := make([]int32, n)
+	for state := 0; state < n; state++ {
+		slotOf[state] = int32(state)
+		current[state] = int32(state)
+	}
+
+	merged, remap := make([]int32, n), make([]int32, n)
+	for i := range merged {
+		merged[i] = -1
+	}
+
+	for i, b := range chunk {
+		class := int32(c.byteClasses[b])
+		for slot, state := range current {
+			if state >= 0 {
+				current[slot] = c.byteTable[state*c.numByteClasses+class]
+			}
+		}
+
+		if i%32 == 31 || i == len(chunk)-1 {
+			current = mergeSlots(current, slotOf, merged, remap)
+		}
+	}
+
+	result := make([]int32, n)
+	for state := 0; state < n; state++ {
+		if slotOf[state] < 0 {
+			result[state] = -1
+			continue
+		}
+		result[state] = current[slotOf[state]]
+	}
+
+	return result
+}
+
+// mergeSlots removes dead and duplicate states from the current slots and
+// points every start state at the slot of its surviving state
+//   - merged and remap are scratch space with one entry per row, allocated once
+//     per chunk, merged is all -1 on entry and is left so
+func mergeSlots(current []int32, slotOf []int32, merged []int32, remap []int32) []int32 {
+	remap = remap[:len(current)]
+	compacted := current[:0]
+	for slot, state := range current {
+		if state < 0 {
+			remap[slot] = -1
+			continue
+		}
+
+		if merged[state] < 0 {
+			merged[state] = int32(len(compacted))
+			compacted = append(compacted, state)
+		}
+		remap[slot] = merged[state]
+	}
+
+	for start, slot := range slotOf {
+		if slot >= 0 {
+			slotOf[start] = remap[slot]
+		}
+	}
+
+	for _, state := range compacted {
+		merged[state] = -1
+	}
+
+	return compacted
+}
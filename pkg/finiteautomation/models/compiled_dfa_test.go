@@ -0,0 +1,238 @@
+package models
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// binaryStrings returns every string over {0, 1} up to the given length.
+func binaryStrings(maxLen int) []string {
+	words := []string{""}
+	for i := 0; i < len(words); i++ {
+		if len(words[i]) < maxLen {
+			words = append(words, words[i]+"0", words[i]+"1")
+		}
+	}
+
+	return words
+}
+
+// TestCompile_MatchesCompute tests that the compiled matcher accepts exactly
+// the inputs Compute accepts.
+func TestCompile_MatchesCompute(t *testing.T) {
+	fa := GetMockFiniteAutomation()
+	c := fa.Compile()
+
+	for _, input := range append(binaryStrings(8), "012", "a") {
+		_, err := fa.Compute(input)
+
+		if c.Match(input) != (err == nil) {
+			t.Errorf("Expected match %t for input %s", err == nil, input)
+		}
+	}
+}
+
+// TestCompile_Output tests that the compiled matcher reaches the state with the
+// same output as Compute.
+func TestCompile_Output(t *testing.T) {
+	fa := GetMockModuloThreeFiniteAutomation()
+	c := fa.Compile()
+
+	state, ok := c.Run("10110")
+
+	if !ok {
+		t.Fatalf("Expected input to run")
+	}
+
+	if c.GetOutput(state) != "1" {
+		t.Errorf("Expected %s, got %s", "1", c.GetOutput(state))
+	}
+}
+
+// TestCompile_ErrorGuarded tests that an automaton with guarded transitions
+// cannot be compiled.
+func TestCompile_ErrorGuarded(t *testing.T) {
+	fa := GetMockGuardedFiniteAutomation()
+
+	if fa.Compile() != nil {
+		t.Errorf("Expected nil compiled automaton")
+	}
+}
+
+// TestCompile_ErrorMultiRuneSymbol tests that an automaton with input symbols
+// longer than one rune cannot be compiled.
+func TestCompile_ErrorMultiRuneSymbol(t *testing.T) {
+	fa := GetMockTokenFiniteAutomation()
+
+	if fa.Compile() != nil {
+		t.Errorf("Expected nil compiled automaton")
+	}
+}
+
+var benchmarkInput = strings.Repeat("1011001110001011", 4)
+
+func BenchmarkCompute(b *testing.B) {
+	fa := GetMockModuloThreeFiniteAutomation()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		fa.Compute(benchmarkInput)
+	}
+}
+
+func BenchmarkCompiledDFA_Match(b *testing.B) {
+	fa := GetMockModuloThreeFiniteAutomation()
+	c := fa.Compile()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		c.Match(benchmarkInput)
+	}
+}
+
+// TestCompile_MatchBytes tests that the byte-level matcher agrees with Match and
+// compresses the 256 bytes into a few classes.
+func TestCompile_MatchBytes(t *testing.T) {
+	fa := GetMockModuloThreeFiniteAutomation()
+	c := fa.Compile()
+
+	for _, input := range append(binaryStrings(8), "012", "\xff") {
+		if c.MatchBytes([]byte(input)) != c.Match(input) {
+			t.Errorf("Expected match %t for input %q", c.Match(input), input)
+		}
+	}
+
+	if c.NumByteClasses() != 3 {
+		t.Errorf("Expected %d byte classes, got %d", 3, c.NumByteClasses())
+	}
+}
+
+// GetMockUnicodeParityFiniteAutomation returns the automaton accepting the
+// strings over a, é, € and 😀 with an even number of non-ASCII runes.
+func GetMockUnicodeParityFiniteAutomation() *FiniteAutomation {
+	even, odd := newState("even"), newState("odd")
+	inputs := map[string]bool{"a": true, "é": true, "€": true, "😀": true}
+	transitionFunctions := []TransitionFunction{}
+	for input := range inputs {
+		for _, from := range []*State{even, odd} {
+			to := from
+			if input != "a" && from == even {
+				to = odd
+			} else if input != "a" {
+				to = even
+			}
+
+			tf := TransitionFunction{}
+			tf.Initialize(from, input, to)
+			transitionFunctions = append(transitionFunctions, tf)
+		}
+	}
+
+	fa, _ := newFiniteAutomation([]*State{even, odd}, inputs, even, []*State{even}, transitionFunctions)
+
+	return fa
+}
+
+// TestCompile_MatchBytesUTF8 tests that multi-byte symbols are matched as their
+// UTF-8 bytes, and that a truncated symbol is not matched.
+func TestCompile_MatchBytesUTF8(t *testing.T) {
+	c := GetMockUnicodeParityFiniteAutomation().Compile()
+
+	for _, input := range []string{"", "a", "é", "éa€", "😀😀", "a😀é€", "é\xa9", "😀"[:3], "é"[:1] + "a", "\xff"} {
+		if c.MatchBytes([]byte(input)) != c.Match(input) {
+			t.Errorf("Expected match %t for input %q", c.Match(input), input)
+		}
+	}
+
+	if _, ok := c.RunBytes([]byte("€"[:2])); ok {
+		t.Errorf("Expected no final state inside a multi-byte symbol")
+	}
+
+	input := []byte(strings.Repeat("a😀é€", 64*1024))
+	for _, suffix := range []string{"", "é", "😀"[:2]} {
+		withSuffix := append(append([]byte{}, input...), suffix...)
+		for _, workers := range []int{3, 7} {
+			if c.MatchParallel(withSuffix, workers) != c.MatchBytes(withSuffix) {
+				t.Errorf("Expected match %t with %d workers", c.MatchBytes(withSuffix), workers)
+			}
+		}
+	}
+}
+
+func BenchmarkCompiledDFA_MatchBytes(b *testing.B) {
+	fa := GetMockModuloThreeFiniteAutomation()
+	c := fa.Compile()
+	input := []byte(benchmarkInput)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		c.MatchBytes(input)
+	}
+}
+
+// TestCompile_MatchParallel tests that the parallel matcher agrees with
+// MatchBytes on long inputs.
+func TestCompile_MatchParallel(t *testing.T) {
+	fa := GetMockModuloThreeFiniteAutomation()
+	c := fa.Compile()
+
+	input := []byte(strings.Repeat(benchmarkInput, 8*1024))
+	for _, suffix := range []string{"", "1", "11", "0"} {
+		withSuffix := append(append([]byte{}, input...), suffix...)
+		for _, workers := range []int{1, 3, 8} {
+			if c.MatchParallel(withSuffix, workers) != c.MatchBytes(withSuffix) {
+				t.Errorf("Expected match %t with %d workers", c.MatchBytes(withSuffix), workers)
+			}
+		}
+	}
+
+	mock := GetMockFiniteAutomation()
+	c = mock.Compile()
+	for _, input := range [][]byte{
+		[]byte(strings.Repeat("01", 256*1024)),
+		[]byte(strings.Repeat("01", 256*1024) + "0"),
+		[]byte(strings.Repeat("01", 128*1024) + "00" + strings.Repeat("01", 128*1024)),
+	} {
+		if c.MatchParallel(input, 4) != c.MatchBytes(input) {
+			t.Errorf("Expected match %t", c.MatchBytes(input))
+		}
+	}
+}
+
+// TestRunSpeculative_Allocations tests that the speculative run allocates its
+// scratch space once, whatever the chunk length.
+func TestRunSpeculative_Allocations(t *testing.T) {
+	fa := GetMockModuloThreeFiniteAutomation()
+	c := fa.Compile()
+
+	shortChunk, longChunk := []byte(benchmarkInput), []byte(strings.Repeat(benchmarkInput, 64))
+	short := testing.AllocsPerRun(10, func() { c.runSpeculative(shortChunk) })
+	long := testing.AllocsPerRun(10, func() { c.runSpeculative(longChunk) })
+
+	if long != short {
+		t.Errorf("Expected %v allocations for a long chunk, got %v", short, long)
+	}
+}
+
+func BenchmarkCompiledDFA_MatchParallel(b *testing.B) {
+	fa := GetMockModuloThreeFiniteAutomation()
+	c := fa.Compile()
+	input := []byte(strings.Repeat(benchmarkInput, 256*1024))
+
+	b.SetBytes(int64(len(input)))
+	for i := 0; i < b.N; i++ {
+		c.MatchParallel(input, runtime.NumCPU())
+	}
+}
+
+func BenchmarkCompiledDFA_MatchBytesLarge(b *testing.B) {
+	fa := GetMockModuloThreeFiniteAutomation()
+	c := fa.Compile()
+	input := []byte(strings.Repeat(benchmarkInput, 256*1024))
+
+	b.SetBytes(int64(len(input)))
+	for i := 0; i < b.N; i++ {
+		c.MatchBytes(input)
+	}
+}
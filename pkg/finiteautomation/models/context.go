@@ -0,0 +1,5 @@
+package models
+
+// Context holds the runtime data that guarded transitions are evaluated against,
+// e.g. {"amount": 120, "total": 100} for an order-processing flow.
+type Context map[string]interface{}
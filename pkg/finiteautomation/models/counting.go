@@ -0,0 +1,162 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"time"
+)
+
+// Function to count the accepted words of exactly length n
+//   - dynamic programming over the transitions, counts can grow beyond int64
+//   - returns 0 if n is negative, the automaton is not initialized or it uses
+//     guarded transitions
+func (fa *FiniteAutomation) CountWords(n int) *big.Int {
+	if n < 0 || fa.checkUnguarded() != nil {
+		return big.NewInt(0)
+	}
+
+	counts := fa.countTable(n)
+
+	return new(big.Int).Set(counts[n][fa.initialState])
+}
+
+// Function to pick an accepted word of exactly length n uniformly at random
+//   - every symbol is drawn with probability proportional to the number of
+//     accepted completions it leads to
+//   - a nil rng is replaced by a source seeded with the current time
+//   - returns an error if no word of length n is accepted
+func (fa *FiniteAutomation) SampleWord(n int, rng *rand.Rand) (string, error) {
+	if err := fa.checkUnguarded(); err != nil {
+		return "", err
+	}
+
+	if n < 0 {
+		return "", errors.New(fmt.Sprintln("Invalid length: ", n))
+	}
+
+	counts := fa.countTable(n)
+	total := counts[n][fa.initialState]
+	if total.Sign() == 0 {
+		return "", errors.New(fmt.Sprintln("No accepted word of length: ", n))
+	}
+
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	index := new(big.Int).Rand(rng, total)
+
+	return fa.unrank(n, index, counts), nil
+}
+
+// Function to rank an accepted word - returns its index among the accepted words
+// of the same length, in lexicographic order over the sorted input symbols
+//   - every rune of the word is one input symbol, like in Compute
+//   - returns an error if the word is not accepted
+func (fa *FiniteAutomation) Rank(word string) (*big.Int, error) {
+	if err := fa.checkUnguarded(); err != nil {
+		return nil, err
+	}
+
+	if _, err := fa.run(runeSymbols(word), nil, nil); err != nil {
+		return nil, err
+	}
+
+	symbols := []rune(word)
+	counts := fa.countTable(len(symbols))
+	inputs := fa.sortedInputs()
+	rank := big.NewInt(0)
+
+	state := fa.initialState
+	for i, char := range symbols {
+		remaining := len(symbols) - i - 1
+		for _, input := range inputs {
+			if input >= string(char) {
+				break
+			}
+
+			if next, ok := state.transition[input]; ok {
+				rank.Add(rank, counts[remaining][next])
+			}
+		}
+		state = state.transition[string(char)]
+	}
+
+	return rank, nil
+}
+
+// Function to unrank an index - returns the accepted word of length n at that
+// index, the inverse of Rank
+//   - returns an error if the index is not lower than CountWords(n)
+func (fa *FiniteAutomation) Unrank(n int, index *big.Int) (string, error) {
+	if err := fa.checkUnguarded(); err != nil {
+		return "", err
+	}
+
+	if n < 0 {
+		return "", errors.New(fmt.Sprintln("Invalid length: ", n))
+	}
+
+	counts := fa.countTable(n)
+	if index == nil || index.Sign() < 0 || index.Cmp(counts[n][fa.initialState]) >= 0 {
+		return "", errors.New(fmt.Sprintln("Invalid index - out of range for length: ", n))
+	}
+
+	return fa.unrank(n, index, counts), nil
+}
+
+// countTable returns, for every length up to n, the number of words of that
+// length leading from every state to an accepting state
+func (fa *FiniteAutomation) countTable(n int) []map[*State]*big.Int {
+	counts := make([]map[*State]*big.Int, n+1)
+	counts[0] = map[*State]*big.Int{}
+	for state := range fa.states {
+		counts[0][state] = big.NewInt(0)
+		if fa.acceptingStates[state] {
+			counts[0][state].SetInt64(1)
+		}
+	}
+
+	for length := 1; length <= n; length++ {
+		counts[length] = map[*State]*big.Int{}
+		for state := range fa.states {
+			count := big.NewInt(0)
+			for _, next := range state.transition {
+				count.Add(count, counts[length-1][next])
+			}
+			counts[length][state] = count
+		}
+	}
+
+	return counts
+}
+
+// unrank returns the accepted word of length n at the index, in lexicographic
+// order over the sorted input symbols
+//   - the index must be lower than the number of accepted words of length n
+func (fa *FiniteAutomation) unrank(n int, index *big.Int, counts []map[*State]*big.Int) string {
+	inputs := fa.sortedInputs()
+	remaining := new(big.Int).Set(index)
+	word := make([]string, 0, n)
+
+	state := fa.initialState
+	for length := n; length > 0; length-- {
+		for _, input := range inputs {
+			next, ok := state.transition[input]
+			if !ok {
+				continue
+			}
+
+			if remaining.Cmp(counts[length-1][next]) < 0 {
+				word = append(word, input)
+				state = next
+				break
+			}
+			remaining.Sub(remaining, counts[length-1][next])
+		}
+	}
+
+	return concat(word)
+}
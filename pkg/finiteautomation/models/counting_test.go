@@ -0,0 +1,135 @@
+package models
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// TestCountWords_NoError tests that the counts match brute force enumeration.
+func TestCountWords_NoError(t *testing.T) {
+	fa := GetMockOnesFiniteAutomation()
+	moduloThree := GetMockModuloThreeFiniteAutomation()
+
+	for n := 0; n <= 6; n++ {
+		expected := int64(0)
+		if n > 0 {
+			expected = 1
+		}
+
+		if fa.CountWords(n).Cmp(big.NewInt(expected)) != 0 {
+			t.Errorf("Expected %d words of length %d, got %s", expected, n, fa.CountWords(n))
+		}
+
+		if moduloThree.CountWords(n).Cmp(big.NewInt(1<<uint(n))) != 0 {
+			t.Errorf("Expected %d words of length %d, got %s", 1<<uint(n), n, moduloThree.CountWords(n))
+		}
+	}
+
+	if moduloThree.CountWords(100).Cmp(new(big.Int).Lsh(big.NewInt(1), 100)) != 0 {
+		t.Errorf("Expected 2^100 words of length 100, got %s", moduloThree.CountWords(100))
+	}
+}
+
+// TestSampleWord_Uniform tests that samples are accepted and spread over all words.
+func TestSampleWord_Uniform(t *testing.T) {
+	fa := GetMockModuloThreeFiniteAutomation()
+	fa.acceptingStates = map[*State]bool{fa.initialState: true} // multiples of three
+	rng := rand.New(rand.NewSource(1))
+
+	seen := map[string]int{}
+	for i := 0; i < 3000; i++ {
+		word, err := fa.SampleWord(4, rng)
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+
+		if _, err := fa.Compute(word); err != nil {
+			t.Errorf("Expected accepted word, got %s", word)
+		}
+		seen[word]++
+	}
+
+	if len(seen) != 6 {
+		t.Errorf("Expected %d distinct words, got %d", 6, len(seen))
+	}
+
+	for word, count := range seen {
+		if count < 400 || count > 600 {
+			t.Errorf("Expected about 500 samples of %s, got %d", word, count)
+		}
+	}
+}
+
+// TestSampleWord_NilRand tests that a nil rng falls back to a time-seeded source.
+func TestSampleWord_NilRand(t *testing.T) {
+	fa := GetMockModuloThreeFiniteAutomation()
+
+	word, err := fa.SampleWord(5, nil)
+
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	if _, err := fa.Compute(word); err != nil || len(word) != 5 {
+		t.Errorf("Expected accepted word of length 5, got %s", word)
+	}
+}
+
+// TestSampleWord_ErrorNoWord tests that an error is returned when no word of the
+// length is accepted.
+func TestSampleWord_ErrorNoWord(t *testing.T) {
+	fa := GetMockFiniteAutomation()
+
+	_, err := fa.SampleWord(3, rand.New(rand.NewSource(1)))
+
+	if err == nil {
+		t.Errorf("Expected error for no accepted word, got nil")
+	}
+}
+
+// TestRank_Bijection tests that Rank and Unrank are inverse and follow
+// lexicographic order.
+func TestRank_Bijection(t *testing.T) {
+	fa := GetMockModuloThreeFiniteAutomation()
+	fa.acceptingStates = map[*State]bool{fa.initialState: true} // multiples of three
+
+	expected := []string{"0000", "0011", "0110", "1001", "1100", "1111"}
+	for i, word := range expected {
+		rank, err := fa.Rank(word)
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+
+		if rank.Cmp(big.NewInt(int64(i))) != 0 {
+			t.Errorf("Expected rank %d for %s, got %s", i, word, rank)
+		}
+
+		result, err := fa.Unrank(4, rank)
+		if err != nil || result != word {
+			t.Errorf("Expected %s for index %d, got %s %v", word, i, result, err)
+		}
+	}
+}
+
+// TestRank_ErrorNotAccepted tests that words that are not accepted cannot be ranked.
+func TestRank_ErrorNotAccepted(t *testing.T) {
+	fa := GetMockFiniteAutomation()
+
+	_, err := fa.Rank("0")
+
+	if err == nil {
+		t.Errorf("Expected error for word not accepted, got nil")
+	}
+}
+
+// TestUnrank_ErrorOutOfRange tests that indexes beyond the word count are rejected.
+func TestUnrank_ErrorOutOfRange(t *testing.T) {
+	fa := GetMockFiniteAutomation()
+
+	_, err := fa.Unrank(4, big.NewInt(1))
+
+	if err == nil {
+		t.Errorf("Expected error for index out of range, got nil")
+	}
+}
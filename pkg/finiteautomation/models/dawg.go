@@ -0,0 +1,200 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// dawgNode is a node of the automaton under construction
+type dawgNode struct {
+	id       int
+	final    bool
+	children map[rune]*dawgNode
+}
+
+// dawgEdge is an edge of the path of the last inserted word that is not
+// minimized yet
+type dawgEdge struct {
+	parent *dawgNode
+	char   rune
+	child  *dawgNode
+}
+
+// DAWGBuilder builds the minimal acyclic automaton of a sorted list of words
+// incrementally (Daciuk et al.), without building the full trie first.
+// It contains:
+//   - root: the initial node.
+//   - register: the minimized nodes, by signature (final flag and outgoing edges).
+//   - unchecked: the edges of the path of the last word not minimized yet.
+//   - previous: the last inserted word, inserted is set once a word was inserted.
+type DAWGBuilder struct {
+	root      *dawgNode
+	register  map[string]*dawgNode
+	unchecked []dawgEdge
+	previous  string
+	inserted  bool
+	nextID    int
+	alphabet  map[string]bool
+}
+
+// Function to create an empty DAWGBuilder
+func NewDAWGBuilder() *DAWGBuilder {
+	b := &DAWGBuilder{register: map[string]*dawgNode{}, alphabet: map[string]bool{}}
+	b.root = b.newNode()
+
+	return b
+}
+
+// Function to build the minimal acyclic automaton of a sorted list of words
+func NewDAWG(words []string) (*FiniteAutomation, error) {
+	b := NewDAWGBuilder()
+	for _, word := range words {
+		if err := b.Insert(word); err != nil {
+			return nil, err
+		}
+	}
+
+	return b.Finish()
+}
+
+// Function to insert the next word
+//   - words must come in increasing lexicographic order, repeated words are ignored
+//   - only the suffix not shared with the previous word is added, the rest of the
+//     previous word is minimized as it can no longer change
+func (b *DAWGBuilder) Insert(word string) error {
+	if b.root == nil {
+		return errors.New("dawg builder has already been finished")
+	}
+
+	if word < b.previous {
+		return errors.New(fmt.Sprintln("Invalid word order - words must be sorted: ", b.previous, " > ", word))
+	}
+
+	if word == b.previous && b.inserted {
+		return nil
+	}
+
+	runes := []rune(word)
+	common := 0
+	for _, char := range b.previous {
+		if common >= len(runes) || runes[common] != char {
+			break
+		}
+		common++
+	}
+
+	b.minimize(common)
+
+	node := b.root
+	if len(b.unchecked) > 0 {
+		node = b.unchecked[len(b.unchecked)-1].child
+	}
+
+	for _, char := range runes[common:] {
+		child := b.newNode()
+		node.children[char] = child
+		b.unchecked = append(b.unchecked, dawgEdge{node, char, child})
+		b.alphabet[string(char)] = true
+		node = child
+	}
+
+	node.final = true
+	b.previous = word
+	b.inserted = true
+
+	return nil
+}
+
+// Function to finish the construction - returns the minimal automaton
+//   - every state outputs an empty string, accepting states are the ends of words
+//   - the builder cannot be used afterwards
+func (b *DAWGBuilder) Finish() (*FiniteAutomation, error) {
+	if b.root == nil {
+		return nil, errors.New("dawg builder has already been finished")
+	}
+
+	b.minimize(0)
+	root := b.root
+	b.root = nil
+	b.register = nil
+
+	states := map[*dawgNode]*State{root: newState("")}
+	order := []*dawgNode{root}
+	transitionFunctions := []TransitionFunction{}
+	for i := 0; i < len(order); i++ {
+		node := order[i]
+		chars := make([]rune, 0, len(node.children))
+		for char := range node.children {
+			chars = append(chars, char)
+		}
+		sort.Slice(chars, func(i, j int) bool { return chars[i] < chars[j] })
+
+		for _, char := range chars {
+			child := node.children[char]
+			if _, ok := states[child]; !ok {
+				states[child] = newState("")
+				order = append(order, child)
+			}
+
+			tf := TransitionFunction{}
+			tf.Initialize(states[node], string(char), states[child])
+			transitionFunctions = append(transitionFunctions, tf)
+		}
+	}
+
+	stateList := make([]*State, 0, len(order))
+	acceptingStates := []*State{}
+	for _, node := range order {
+		stateList = append(stateList, states[node])
+		if node.final {
+			acceptingStates = append(acceptingStates, states[node])
+		}
+	}
+
+	return newFiniteAutomation(stateList, b.alphabet, states[root], acceptingStates, transitionFunctions)
+}
+
+// minimize replaces the unchecked nodes below depth downTo by their registered
+// equivalent, or registers them, deepest first
+func (b *DAWGBuilder) minimize(downTo int) {
+	for i := len(b.unchecked) - 1; i >= downTo; i-- {
+		edge := b.unchecked[i]
+		key := edge.child.signature()
+		if registered, ok := b.register[key]; ok {
+			edge.parent.children[edge.char] = registered
+		} else {
+			b.register[key] = edge.child
+		}
+	}
+
+	b.unchecked = b.unchecked[:downTo]
+}
+
+func (b *DAWGBuilder) newNode() *dawgNode {
+	node := &dawgNode{id: b.nextID, children: map[rune]*dawgNode{}}
+	b.nextID++
+
+	return node
+}
+
+// signature identifies a node by its final flag and its outgoing edges
+func (node *dawgNode) signature() string {
+	chars := make([]rune, 0, len(node.children))
+	for char := range node.children {
+		chars = append(chars, char)
+	}
+	sort.Slice(chars, func(i, j int) bool { return chars[i] < chars[j] })
+
+	var key strings.Builder
+	key.WriteString(strconv.FormatBool(node.final))
+	for _, char := range chars {
+		key.WriteByte('|')
+		key.WriteString(strconv.QuoteRune(char))
+		key.WriteString(strconv.Itoa(node.children[char].id))
+	}
+
+	return key.String()
+}
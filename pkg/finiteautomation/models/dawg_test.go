@@ -0,0 +1,64 @@
+package models
+
+import "testing"
+
+// TestNewDAWG_Minimal tests that shared prefixes and suffixes are merged.
+func TestNewDAWG_Minimal(t *testing.T) {
+	fa, err := NewDAWG([]string{"tap", "taps", "top", "tops"})
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	if len(fa.states) != 5 {
+		t.Errorf("Expected %d states, got %d", 5, len(fa.states))
+	}
+
+	for input, expected := range map[string]bool{"tap": true, "tops": true, "ta": false, "tapss": false, "": false} {
+		_, err := fa.Compute(input)
+
+		if (err == nil) != expected {
+			t.Errorf("Expected %t for input %s", expected, input)
+		}
+	}
+}
+
+// TestNewDAWG_MatchesMinimization tests that the builder produces as many states
+// as minimizing the automaton afterwards.
+func TestNewDAWG_MatchesMinimization(t *testing.T) {
+	words := []string{"", "0", "00", "01", "0101", "10", "1010", "11", "110", "111"}
+	fa, err := NewDAWG(words)
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	minimized, err := fa.MinimizeTransducer()
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	if len(fa.states) != len(minimized.states) {
+		t.Errorf("Expected %d states, got %d", len(minimized.states), len(fa.states))
+	}
+
+	accepted := map[string]bool{}
+	for _, word := range words {
+		accepted[word] = true
+	}
+
+	for _, input := range binaryStrings(5) {
+		_, err := fa.Compute(input)
+
+		if (err == nil) != accepted[input] {
+			t.Errorf("Expected %t for input %s", accepted[input], input)
+		}
+	}
+}
+
+// TestNewDAWG_ErrorUnsorted tests that unsorted words are rejected.
+func TestNewDAWG_ErrorUnsorted(t *testing.T) {
+	_, err := NewDAWG([]string{"b", "a"})
+
+	if err == nil {
+		t.Errorf("Expected error for unsorted words, got nil")
+	}
+}
@@ -0,0 +1,464 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Regex is a regular expression over runes matched with Brzozowski derivatives.
+// Regexes are built by the smart constructors below, which keep them normalized
+// (associativity, commutativity and idempotence of union and intersection, plus
+// the identities of the empty set and the empty string), so every regex has
+// finitely many distinct derivatives.
+// It contains:
+//   - kind: the operator of the node.
+//   - ranges, negated: for a set of runes, the inclusive ranges matched, or not
+//     matched when negated is set.
+//   - children: the operands of concatenation, union, intersection, complement
+//     and star.
+//   - key: the canonical string of the regex, equal for equal regexes.
+type Regex struct {
+	kind     regexKind
+	ranges   [][2]rune
+	negated  bool
+	children []*Regex
+	key      string
+}
+
+var (
+	regexEmptySet = &Regex{kind: regexChars, key: "∅"}
+	regexEpsilon  = &Regex{kind: regexEmpty, key: "ε"}
+)
+
+// Function to parse a pattern into a Regex
+//   - supports the syntax of CompileRegex, plus intersection '&' (binding
+//     tighter than '|') and the prefix complement '~'
+func ParseRegex(pattern string) (*Regex, error) {
+	node, err := parseRegex(pattern, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return node.toRegex(), nil
+}
+
+// toRegex converts a parsed node with the smart constructors
+func (re *regexNode) toRegex() *Regex {
+	children := make([]*Regex, len(re.children))
+	for i, child := range re.children {
+		children[i] = child.toRegex()
+	}
+
+	switch re.kind {
+	case regexEmpty:
+		return RegexEpsilon()
+	case regexChars:
+		return newRegexChars(re.ranges, re.negated)
+	case regexConcat:
+		return RegexConcat(children...)
+	case regexUnion:
+		return RegexUnion(children...)
+	case regexIntersect:
+		return RegexIntersect(children...)
+	case regexComplement:
+		return RegexComplement(children[0])
+	case regexStar:
+		return RegexStar(children[0])
+	case regexPlus:
+		return RegexConcat(children[0], RegexStar(children[0]))
+	default:
+		return RegexUnion(children[0], RegexEpsilon())
+	}
+}
+
+// Function to get the regex matching nothing
+func RegexEmptySet() *Regex {
+	return regexEmptySet
+}
+
+// Function to get the regex matching only the empty string
+func RegexEpsilon() *Regex {
+	return regexEpsilon
+}
+
+// Function to get the regex matching a single rune
+func RegexSymbol(char rune) *Regex {
+	return newRegexChars([][2]rune{{char, char}}, false)
+}
+
+// Function to get the regex matching any rune between low and high inclusive
+func RegexRange(low rune, high rune) *Regex {
+	return newRegexChars([][2]rune{{low, high}}, false)
+}
+
+// Function to get the regex matching the concatenation of the regexes
+//   - the empty set absorbs, the empty string is dropped
+func RegexConcat(regexes ...*Regex) *Regex {
+	children := []*Regex{}
+	for _, regex := range regexes {
+		switch {
+		case regex == regexEmptySet:
+			return regexEmptySet
+		case regex == regexEpsilon:
+		case regex.kind == regexConcat:
+			children = append(children, regex.children...)
+		default:
+			children = append(children, regex)
+		}
+	}
+
+	switch len(children) {
+	case 0:
+		return regexEpsilon
+	case 1:
+		return children[0]
+	}
+
+	keys := make([]string, len(children))
+	for i, child := range children {
+		keys[i] = child.group(regexConcat)
+	}
+
+	return &Regex{kind: regexConcat, children: children, key: strings.Join(keys, "")}
+}
+
+// Function to get the regex matching any of the regexes
+//   - operands are flattened, sorted and deduplicated, the empty set is dropped
+//     and the complement of the empty set absorbs
+func RegexUnion(regexes ...*Regex) *Regex {
+	return newRegexSet(regexUnion, "|", regexEmptySet, RegexComplement(regexEmptySet), regexes)
+}
+
+// Function to get the regex matching all of the regexes
+//   - operands are flattened, sorted and deduplicated, the complement of the
+//     empty set is dropped and the empty set absorbs
+func RegexIntersect(regexes ...*Regex) *Regex {
+	return newRegexSet(regexIntersect, "&", RegexComplement(regexEmptySet), regexEmptySet, regexes)
+}
+
+// Function to get the regex matching the strings the regex does not match
+//   - a double complement cancels out
+func RegexComplement(regex *Regex) *Regex {
+	if regex.kind == regexComplement {
+		return regex.children[0]
+	}
+
+	return &Regex{kind: regexComplement, children: []*Regex{regex}, key: "~" + regex.group(regexComplement)}
+}
+
+// Function to get the regex matching zero or more repetitions of the regex
+//   - the star of the empty set or the empty string is the empty string, a
+//     star of a star is the same star
+func RegexStar(regex *Regex) *Regex {
+	switch {
+	case regex == regexEmptySet || regex == regexEpsilon:
+		return regexEpsilon
+	case regex.kind == regexStar:
+		return regex
+	}
+
+	return &Regex{kind: regexStar, children: []*Regex{regex}, key: regex.group(regexStar) + "*"}
+}
+
+// newRegexChars builds a set of runes with sorted, merged ranges
+func newRegexChars(ranges [][2]rune, negated bool) *Regex {
+	sorted := make([][2]rune, len(ranges))
+	copy(sorted, ranges)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i][0] < sorted[j][0] })
+
+	merged := [][2]rune{}
+	for _, r := range sorted {
+		if r[0] > r[1] {
+			continue
+		}
+		if last := len(merged) - 1; last >= 0 && r[0] <= merged[last][1]+1 {
+			if r[1] > merged[last][1] {
+				merged[last][1] = r[1]
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+
+	if len(merged) == 0 && !negated {
+		return regexEmptySet
+	}
+
+	if len(merged) == 1 && merged[0][0] == merged[0][1] && !negated {
+		return &Regex{kind: regexChars, ranges: merged, key: escapeRegexRune(merged[0][0], false)}
+	}
+
+	var key strings.Builder
+	key.WriteString("[")
+	if negated {
+		key.WriteString("^")
+	}
+	for _, r := range merged {
+		key.WriteString(escapeRegexRune(r[0], true))
+		if r[1] > r[0] {
+			key.WriteString("-" + escapeRegexRune(r[1], true))
+		}
+	}
+	key.WriteString("]")
+
+	return &Regex{kind: regexChars, ranges: merged, negated: negated, key: key.String()}
+}
+
+// newRegexSet builds a union or intersection with the given identity and
+// absorbing operands
+func newRegexSet(kind regexKind, separator string, identity *Regex, absorbing *Regex, regexes []*Regex) *Regex {
+	operands := map[string]*Regex{}
+	var add func(regex *Regex) bool
+	add = func(regex *Regex) bool {
+		switch {
+		case regex.key == absorbing.key:
+			return false
+		case regex.key == identity.key:
+		case regex.kind == kind:
+			for _, child := range regex.children {
+				add(child)
+			}
+		default:
+			operands[regex.key] = regex
+		}
+		return true
+	}
+
+	for _, regex := range regexes {
+		if !add(regex) {
+			return absorbing
+		}
+	}
+
+	switch len(operands) {
+	case 0:
+		return identity
+	case 1:
+		for _, operand := range operands {
+			return operand
+		}
+	}
+
+	keys := make([]string, 0, len(operands))
+	for key := range operands {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	children := make([]*Regex, len(keys))
+	for i, key := range keys {
+		children[i] = operands[key]
+		keys[i] = children[i].group(kind)
+	}
+
+	return &Regex{kind: kind, children: children, key: strings.Join(keys, separator)}
+}
+
+// group returns the key of the regex, parenthesized if it binds looser than an
+// operand of the parent kind
+func (r *Regex) group(parent regexKind) string {
+	loose := false
+	switch r.kind {
+	case regexUnion:
+		loose = parent != regexUnion
+	case regexIntersect:
+		loose = parent != regexIntersect && parent != regexUnion
+	case regexConcat:
+		loose = parent == regexStar || parent == regexComplement
+	case regexComplement:
+		loose = parent == regexStar
+	}
+
+	if loose {
+		return "(" + r.key + ")"
+	}
+
+	return r.key
+}
+
+// escapeRegexRune writes a rune so that it parses back as a literal
+func escapeRegexRune(char rune, inClass bool) string {
+	switch char {
+	case '\n':
+		return `\n`
+	case '\t':
+		return `\t`
+	case '\r':
+		return `\r`
+	}
+
+	metacharacters := `\.[]()|&~*+?ε∅`
+	if inClass {
+		metacharacters = `\]^-`
+	}
+	if strings.ContainsRune(metacharacters, char) {
+		return `\` + string(char)
+	}
+
+	return string(char)
+}
+
+// Function to get the canonical form of the regex, equal regexes have the same
+// string
+func (r *Regex) String() string {
+	return r.key
+}
+
+// Function to check if the regex matches the empty string
+func (r *Regex) Nullable() bool {
+	switch r.kind {
+	case regexEmpty, regexStar:
+		return true
+	case regexChars:
+		return false
+	case regexComplement:
+		return !r.children[0].Nullable()
+	case regexUnion:
+		for _, child := range r.children {
+			if child.Nullable() {
+				return true
+			}
+		}
+		return false
+	default:
+		for _, child := range r.children {
+			if !child.Nullable() {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Function to get the Brzozowski derivative of the regex by a rune - the regex
+// matching w for every char w the regex matches
+func (r *Regex) Derivative(char rune) *Regex {
+	switch r.kind {
+	case regexEmpty:
+		return regexEmptySet
+	case regexChars:
+		for _, rr := range r.ranges {
+			if char >= rr[0] && char <= rr[1] {
+				if r.negated {
+					return regexEmptySet
+				}
+				return regexEpsilon
+			}
+		}
+		if r.negated {
+			return regexEpsilon
+		}
+		return regexEmptySet
+	case regexConcat:
+		head, tail := r.children[0], RegexConcat(r.children[1:]...)
+		derivative := RegexConcat(head.Derivative(char), tail)
+		if head.Nullable() {
+			derivative = RegexUnion(derivative, tail.Derivative(char))
+		}
+		return derivative
+	case regexStar:
+		return RegexConcat(r.children[0].Derivative(char), r)
+	case regexComplement:
+		return RegexComplement(r.children[0].Derivative(char))
+	default:
+		derivatives := make([]*Regex, len(r.children))
+		for i, child := range r.children {
+			derivatives[i] = child.Derivative(char)
+		}
+		if r.kind == regexUnion {
+			return RegexUnion(derivatives...)
+		}
+		return RegexIntersect(derivatives...)
+	}
+}
+
+// Function to check if the regex matches the whole input, deriving lazily rune by
+// rune without building an automaton
+func (r *Regex) Matches(input string) bool {
+	current := r
+	for _, char := range input {
+		current = current.Derivative(char)
+		if current == regexEmptySet {
+			return false
+		}
+	}
+
+	return current.Nullable()
+}
+
+// Function to build the DFA of the regex - every distinct derivative becomes a
+// state outputting the derivative's canonical string
+//   - the alphabet symbols must be single runes, a nil alphabet uses the
+//     CompileRegex alphabet, printable ASCII and whitespace plus the runes of the regex
+//   - transitions to the empty set are left undefined
+func (r *Regex) ToAutomaton(alphabet map[string]bool) (*FiniteAutomation, error) {
+	if alphabet == nil {
+		alphabet = defaultRegexAlphabet()
+		r.collectAlphabet(alphabet)
+	}
+
+	for symbol := range alphabet {
+		if utf8.RuneCountInString(symbol) != 1 {
+			return nil, errors.New(fmt.Sprintln("Invalid alphabet - symbol", symbol, "is not a single rune"))
+		}
+	}
+	symbols := sortedSymbols(alphabet)
+
+	inputs := make(map[string]bool, len(symbols))
+	for _, symbol := range symbols {
+		inputs[symbol] = true
+	}
+
+	states := map[string]*State{r.key: newState(r.key)}
+	ordered := []*State{states[r.key]}
+	accepting := []*State{}
+	transitionFunctions := []TransitionFunction{}
+	for queue := []*Regex{r}; len(queue) > 0; queue = queue[1:] {
+		current := queue[0]
+		if current.Nullable() {
+			accepting = append(accepting, states[current.key])
+		}
+
+		for _, symbol := range symbols {
+			char, _ := utf8.DecodeRuneInString(symbol)
+			derivative := current.Derivative(char)
+			if derivative == regexEmptySet {
+				continue
+			}
+
+			target, ok := states[derivative.key]
+			if !ok {
+				target = newState(derivative.key)
+				states[derivative.key] = target
+				ordered = append(ordered, target)
+				queue = append(queue, derivative)
+			}
+
+			tf := TransitionFunction{}
+			tf.Initialize(states[current.key], symbol, target)
+			transitionFunctions = append(transitionFunctions, tf)
+		}
+	}
+
+	return newFiniteAutomation(ordered, inputs, ordered[0], accepting, transitionFunctions)
+}
+
+// collectAlphabet adds the runes of the non-negated sets to the alphabet
+func (r *Regex) collectAlphabet(alphabet map[string]bool) {
+	if r.kind == regexChars && !r.negated {
+		for _, rr := range r.ranges {
+			if rr[1]-rr[0] < 256 {
+				for char := rr[0]; char <= rr[1]; char++ {
+					alphabet[string(char)] = true
+				}
+			}
+		}
+	}
+
+	for _, child := range r.children {
+		child.collectAlphabet(alphabet)
+	}
+}
@@ -0,0 +1,159 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestParseRegex_Matches tests lazy matching against the standard library on
+// patterns without intersection and complement.
+func TestParseRegex_Matches(t *testing.T) {
+	for _, pattern := range []string{"1+(01)*", "(0|1)*1(0|1)?", "0?1*0?", "(00|1)*", ""} {
+		regex, err := ParseRegex(pattern)
+		if err != nil {
+			t.Fatalf("Expected nil error for %s, got %v", pattern, err)
+		}
+
+		re := regexp.MustCompile("^(" + pattern + ")$")
+		for _, input := range binaryStrings(7) {
+			if regex.Matches(input) != re.MatchString(input) {
+				t.Errorf("Expected %t for pattern %s and input %s", re.MatchString(input), pattern, input)
+			}
+		}
+	}
+}
+
+// TestParseRegex_IntersectComplement tests patterns using '&' and '~'.
+func TestParseRegex_IntersectComplement(t *testing.T) {
+	for pattern, expected := range map[string]func(string) bool{
+		"(0|1)*1(0|1)*&(0|1)*0": func(input string) bool {
+			return strings.Contains(input, "1") && strings.HasSuffix(input, "0")
+		},
+		"~((0|1)*11(0|1)*)": func(input string) bool {
+			return !strings.Contains(input, "11")
+		},
+		"~(0*)&(0|1)*": func(input string) bool {
+			return strings.Contains(input, "1")
+		},
+		"~(0|1)*": func(input string) bool {
+			return false
+		},
+	} {
+		regex, err := ParseRegex(pattern)
+		if err != nil {
+			t.Fatalf("Expected nil error for %s, got %v", pattern, err)
+		}
+
+		for _, input := range binaryStrings(7) {
+			if regex.Matches(input) != expected(input) {
+				t.Errorf("Expected %t for pattern %s and input %s", expected(input), pattern, input)
+			}
+		}
+	}
+}
+
+// TestRegex_String tests that the smart constructors normalize equal regexes to
+// the same canonical string.
+func TestRegex_String(t *testing.T) {
+	for left, right := range map[string]string{
+		"b|a|b":     "a|b",
+		"(a|b)|c":   "c|(b|a)",
+		"a&b&a":     "b&a",
+		"(a*)*":     "a*",
+		"~~a":       "a",
+		"a()b":      "ab",
+		"[a-cb-d]":  "[a-d]",
+		"(a|b)*a&c": "c&(a|b)*a",
+	} {
+		l, err := ParseRegex(left)
+		if err != nil {
+			t.Fatalf("Expected nil error for %s, got %v", left, err)
+		}
+		r, err := ParseRegex(right)
+		if err != nil {
+			t.Fatalf("Expected nil error for %s, got %v", right, err)
+		}
+
+		if l.String() != r.String() {
+			t.Errorf("Expected %s and %s to normalize equally, got %s and %s", left, right, l, r)
+		}
+	}
+
+	if RegexConcat(RegexSymbol('a'), RegexEmptySet()) != RegexEmptySet() {
+		t.Errorf("Expected concatenation with the empty set to be the empty set")
+	}
+	if RegexStar(RegexEmptySet()) != RegexEpsilon() {
+		t.Errorf("Expected star of the empty set to be the empty string")
+	}
+}
+
+// TestRegex_Derivative tests derivatives by a rune.
+func TestRegex_Derivative(t *testing.T) {
+	regex, _ := ParseRegex("ab*|b")
+
+	if got := regex.Derivative('a').String(); got != "b*" {
+		t.Errorf("Expected b*, got %s", got)
+	}
+	if !regex.Derivative('b').Nullable() {
+		t.Errorf("Expected derivative by b to be nullable")
+	}
+	if regex.Derivative('c') != RegexEmptySet() {
+		t.Errorf("Expected derivative by c to be the empty set")
+	}
+}
+
+// TestRegex_ToAutomaton tests the DFA built from derivative classes.
+func TestRegex_ToAutomaton(t *testing.T) {
+	regex, _ := ParseRegex("~((0|1)*11(0|1)*)")
+	fa, err := regex.ToAutomaton(map[string]bool{"0": true, "1": true})
+
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	if fa.initialState.output != regex.String() {
+		t.Errorf("Expected initial state output %s, got %s", regex, fa.initialState.output)
+	}
+
+	// Derivative classes are syntactic, the sink after 11 appears twice.
+	if len(fa.states) != 4 {
+		t.Errorf("Expected 4 states, got %d", len(fa.states))
+	}
+
+	for _, input := range binaryStrings(7) {
+		_, err := fa.Compute(input)
+
+		if (err == nil) != regex.Matches(input) {
+			t.Errorf("Expected %t for input %s", regex.Matches(input), input)
+		}
+	}
+}
+
+// TestRegex_ToAutomaton_DefaultAlphabet tests the DFA over the default alphabet.
+func TestRegex_ToAutomaton_DefaultAlphabet(t *testing.T) {
+	regex, _ := ParseRegex("[a-z]+&~(if|for)")
+	fa, err := regex.ToAutomaton(nil)
+
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	for input, expected := range map[string]bool{"iff": true, "fo": true, "if": false, "for": false, "": false, "A": false} {
+		_, err := fa.Compute(input)
+
+		if (err == nil) != expected {
+			t.Errorf("Expected %t for input %s", expected, input)
+		}
+	}
+}
+
+// TestRegex_ToAutomaton_Error tests that multi-rune symbols are rejected.
+func TestRegex_ToAutomaton_Error(t *testing.T) {
+	regex, _ := ParseRegex("ab")
+	_, err := regex.ToAutomaton(map[string]bool{"ab": true})
+
+	if err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+}
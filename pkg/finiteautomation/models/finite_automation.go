@@ -3,6 +3,7 @@ package models
 import (
 	"errors"
 	"fmt"
+	"sort"
 )
 
 // FiniteAutomation defines a finite automaton model.
@@ -12,12 +13,16 @@ import (
 // - initialState: the starting state of the automaton.
 // - acceptingStates: the set of final states that signify acceptance of input.
 // - transitionFunctions: a list of all defined transitions between states.
+// - core: the generic automaton the inputs are run through, built on initialization.
+// - refs: the state of every id of core.
 type FiniteAutomation struct {
 	states              map[*State]*State
 	inputs              map[string]bool
 	initialState        *State
 	acceptingStates     map[*State]bool
 	transitionFunctions []TransitionFunction
+	core                *Automaton[string, string]
+	refs                []*State
 }
 
 // Function to initialize the FiniteAutomation
@@ -59,10 +64,21 @@ func (fa *FiniteAutomation) InitializeFiniteAutomation(
 		fa.acceptingStates[acceptingState] = true
 	}
 
+	for state := range fa.states {
+		state.guardedTransitions = nil
+	}
+
 	for _, transitionFunction := range fa.transitionFunctions {
-		transitionFunction.currentState.addTransition(transitionFunction.input, transitionFunction.transitionState)
+		if transitionFunction.IsGuarded() {
+			transitionFunction.currentState.addGuardedTransition(transitionFunction)
+			continue
+		}
+
+		transitionFunction.currentState.addTransition(transitionFunction.input, transitionFunction.transitionState, transitionFunction.output)
 	}
 
+	fa.core, fa.refs = fa.buildCore()
+
 	return nil
 }
 
@@ -70,31 +86,185 @@ func (fa *FiniteAutomation) InitializeFiniteAutomation(
 // - check if there is any nil pointer in the attributes - return error if there is
 // - check if the last state is in the list of accepting states - return error if it's not
 func (fa *FiniteAutomation) Compute(input string) (*string, error) {
-	if fa == nil || fa.states == nil || fa.initialState == nil || fa.acceptingStates == nil {
-		return nil, errors.New("finite Automation has not been initialized")
+	return fa.ComputeWithContext(input, nil)
+}
+
+// Function to compute the final state with runtime data for guarded transitions
+//   - guarded transitions are evaluated in declaration order before the unguarded one
+//   - same errors as Compute
+func (fa *FiniteAutomation) ComputeWithContext(input string, ctx Context) (*string, error) {
+	ref, err := fa.run(runeSymbols(input), ctx, nil)
+	if err != nil {
+		return nil, err
 	}
 
-	ref := fa.initialState
-	for _, char := range input {
-		s := string(char)
-		_, isInputValid := fa.inputs[s]
-		if !isInputValid {
-			return nil, errors.New(fmt.Sprintln("Invalid input: ", s))
-		}
+	result := ref.GetOutput()
 
-		_, isTransitionValid := ref.transition[s]
-		if !isTransitionValid {
-			return nil, errors.New(fmt.Sprintln("Invalid transition: ", s))
-		}
+	return &result, nil
+}
 
-		ref = ref.transition[s]
+// Function to compute the Moore output sequence - returns the output of every
+// visited state, starting with the initial state
+//   - same errors as Compute
+func (fa *FiniteAutomation) Transduce(input string) ([]string, error) {
+	return fa.TransduceWithContext(input, nil)
+}
+
+// Function to compute the Moore output sequence with runtime data for guarded
+// transitions
+//   - guarded transitions are evaluated like in ComputeWithContext
+//   - same errors as Compute
+func (fa *FiniteAutomation) TransduceWithContext(input string, ctx Context) ([]string, error) {
+	if fa == nil || fa.initialState == nil {
+		return nil, errors.New("finite Automation has not been initialized")
 	}
 
-	if _, valid := fa.acceptingStates[ref]; !valid {
-		return nil, errors.New(fmt.Sprintln("Invalid final state - not in the list of accepting state -", ref.output))
+	outputs := []string{fa.initialState.GetOutput()}
+
+	_, err := fa.run(runeSymbols(input), ctx, func(transitionOutput string, state *State) {
+		outputs = append(outputs, state.GetOutput())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return outputs, nil
+}
+
+// Function to compute the final state of a sequence of input symbols - returns the
+// value of the final state
+//   - unlike Compute, every element is one input symbol, so symbols longer than one
+//     rune (e.g. "DIGIT", "SEP") can be used
+//   - same errors as Compute
+func (fa *FiniteAutomation) ComputeSymbols(symbols []string) (*string, error) {
+	ref, err := fa.run(symbols, nil, nil)
+	if err != nil {
+		return nil, err
 	}
 
 	result := ref.GetOutput()
 
 	return &result, nil
 }
+
+// run feeds the input symbols through the generic automaton and returns the
+// accepting state it ends in
+//   - visit, when not nil, is called with the output of every transition taken and
+//     the state it leads to
+//   - returns the same errors as Compute
+func (fa *FiniteAutomation) run(symbols []string, ctx Context, visit func(transitionOutput string, state *State)) (*State, error) {
+	if fa == nil || fa.states == nil || fa.initialState == nil || fa.acceptingStates == nil || fa.core == nil {
+		return nil, errors.New("finite Automation has not been initialized")
+	}
+
+	var visitCore func(transitionOutput string, state int)
+	if visit != nil {
+		visitCore = func(transitionOutput string, state int) {
+			visit(transitionOutput, fa.refs[state])
+		}
+	}
+
+	state, err := fa.core.run(symbols, ctx, visitCore)
+	if err != nil {
+		return nil, err
+	}
+
+	return fa.refs[state], nil
+}
+
+// runeSymbols splits the input into one input symbol per rune
+func runeSymbols(input string) []string {
+	symbols := make([]string, 0, len(input))
+	for _, char := range input {
+		symbols = append(symbols, string(char))
+	}
+
+	return symbols
+}
+
+// newFiniteAutomation builds and initializes a FiniteAutomation from a list of states
+func newFiniteAutomation(
+	states []*State,
+	inputs map[string]bool,
+	initialState *State,
+	acceptingStates []*State,
+	transitionFunctions []TransitionFunction,
+) (*FiniteAutomation, error) {
+	finiteStates := make(map[*State]*State, len(states))
+	for _, state := range states {
+		finiteStates[state] = state
+	}
+
+	if acceptingStates == nil {
+		acceptingStates = []*State{}
+	}
+
+	fa := &FiniteAutomation{}
+	err := fa.InitializeFiniteAutomation(finiteStates, inputs, initialState, acceptingStates, transitionFunctions)
+	if err != nil {
+		return nil, err
+	}
+
+	return fa, nil
+}
+
+// sortedInputs returns the input symbols in lexicographic order
+func (fa *FiniteAutomation) sortedInputs() []string {
+	return sortedSymbols(fa.inputs)
+}
+
+// sortedSymbols returns the symbols of a set in lexicographic order
+func sortedSymbols(symbols map[string]bool) []string {
+	sorted := make([]string, 0, len(symbols))
+	for symbol := range symbols {
+		sorted = append(sorted, symbol)
+	}
+
+	sort.Strings(sorted)
+
+	return sorted
+}
+
+// orderedStates returns the states in a deterministic order
+//   - states reachable from the initial state come first, in breadth-first order
+//     over the sorted inputs, guarded targets in declaration order
+//   - unreachable states follow, sorted by output
+func (fa *FiniteAutomation) orderedStates() []*State {
+	inputs := fa.sortedInputs()
+	ordered := []*State{fa.initialState}
+	seen := map[*State]bool{fa.initialState: true}
+
+	for i := 0; i < len(ordered); i++ {
+		state := ordered[i]
+		for _, input := range inputs {
+			targets := []*State{}
+			for _, transitionFunction := range state.guardedTransitions[input] {
+				targets = append(targets, transitionFunction.transitionState)
+			}
+
+			if next, ok := state.transition[input]; ok {
+				targets = append(targets, next)
+			}
+
+			for _, next := range targets {
+				if !seen[next] {
+					seen[next] = true
+					ordered = append(ordered, next)
+				}
+			}
+		}
+	}
+
+	unreachable := []*State{}
+	for state := range fa.states {
+		if !seen[state] {
+			unreachable = append(unreachable, state)
+		}
+	}
+
+	sort.SliceStable(unreachable, func(i, j int) bool {
+		return unreachable[i].output < unreachable[j].output
+	})
+
+	return append(ordered, unreachable...)
+}
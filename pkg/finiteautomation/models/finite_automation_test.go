@@ -139,6 +139,37 @@ func TestInitializeFiniteAutomation_InvalidTransitionFunction(t *testing.T) {
 	}
 }
 
+// TestInitializeFiniteAutomation_UnguardedOverwrite tests that a later unguarded
+// transition on the same state and input overwrites the earlier one.
+func TestInitializeFiniteAutomation_UnguardedOverwrite(t *testing.T) {
+	state1, state2 := State{}, State{}
+	state1.Initialize("0", map[string]*State{})
+	state2.Initialize("1", map[string]*State{})
+
+	tf1 := TransitionFunction{}
+	tf1.Initialize(&state1, "0", &state1)
+	tf2 := TransitionFunction{}
+	tf2.Initialize(&state1, "0", &state2) // <-- same state and input as tf1, different target
+	transitionFunctions := []TransitionFunction{tf1, tf2}
+
+	finiteStates := map[*State]*State{&state1: &state1, &state2: &state2}
+	fa := FiniteAutomation{}
+	err := fa.InitializeFiniteAutomation(finiteStates, map[string]bool{"0": true}, &state1, []*State{&state2}, transitionFunctions)
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	result, err := fa.Compute("0")
+
+	if err != nil {
+		t.Errorf("Expected nil error, got %v", err)
+	}
+
+	if *result != "1" {
+		t.Errorf("Expected %s, got %s", "1", *result)
+	}
+}
+
 func GetMockFiniteAutomation() FiniteAutomation {
 	state1, state2 := State{}, State{}
 	state1.Initialize("0", map[string]*State{})
@@ -257,3 +288,245 @@ func TestComput_ErrorInvalidFinalState(t *testing.T) {
 		t.Errorf("Expected nil result, got %s", *result)
 	}
 }
+
+func GetMockGuardedFiniteAutomation() FiniteAutomation {
+	pending, paid, partiallyPaid := State{}, State{}, State{}
+	pending.Initialize("Pending", map[string]*State{})
+	paid.Initialize("Paid", map[string]*State{})
+	partiallyPaid.Initialize("PartiallyPaid", map[string]*State{})
+
+	tf1 := TransitionFunction{}
+	tf1.Initialize(&pending, "P", &paid)
+	tf1.SetGuard(func(ctx Context) bool {
+		return ctx["amount"].(int) >= ctx["total"].(int)
+	})
+	tf2 := TransitionFunction{}
+	tf2.Initialize(&pending, "P", &partiallyPaid)
+	tf3 := TransitionFunction{}
+	tf3.Initialize(&partiallyPaid, "P", &paid)
+	transitionFunctions := []TransitionFunction{tf1, tf2, tf3}
+
+	finiteStates := map[*State]*State{&pending: &pending, &paid: &paid, &partiallyPaid: &partiallyPaid}
+	acceptingStates := []*State{&paid, &partiallyPaid}
+	inputs := map[string]bool{"P": true}
+
+	fa := FiniteAutomation{}
+	fa.InitializeFiniteAutomation(finiteStates, inputs, &pending, acceptingStates, transitionFunctions)
+
+	return fa
+}
+
+// TestComputeWithContext_GuardTaken tests that a guarded transition is taken
+// when its guard holds for the given context.
+func TestComputeWithContext_GuardTaken(t *testing.T) {
+	fa := GetMockGuardedFiniteAutomation()
+
+	result, err := fa.ComputeWithContext("P", Context{"amount": 100, "total": 100})
+
+	if err != nil {
+		t.Errorf("Expected nil error, got %v", err)
+	}
+
+	if *result != "Paid" {
+		t.Errorf("Expected %s, got %s", "Paid", *result)
+	}
+}
+
+// TestComputeWithContext_GuardFallback tests that the unguarded transition is
+// taken when no guard on the same input holds.
+func TestComputeWithContext_GuardFallback(t *testing.T) {
+	fa := GetMockGuardedFiniteAutomation()
+
+	result, err := fa.ComputeWithContext("P", Context{"amount": 40, "total": 100})
+
+	if err != nil {
+		t.Errorf("Expected nil error, got %v", err)
+	}
+
+	if *result != "PartiallyPaid" {
+		t.Errorf("Expected %s, got %s", "PartiallyPaid", *result)
+	}
+}
+
+// TestComputeWithContext_GuardPriority tests that guarded transitions on the
+// same input are evaluated in declaration order.
+func TestComputeWithContext_GuardPriority(t *testing.T) {
+	state1, state2, state3 := State{}, State{}, State{}
+	state1.Initialize("0", map[string]*State{})
+	state2.Initialize("1", map[string]*State{})
+	state3.Initialize("2", map[string]*State{})
+
+	always := func(ctx Context) bool { return true }
+	tf1 := TransitionFunction{}
+	tf1.Initialize(&state1, "0", &state2)
+	tf1.SetGuard(always)
+	tf2 := TransitionFunction{}
+	tf2.Initialize(&state1, "0", &state3)
+	tf2.SetGuard(always)
+
+	finiteStates := map[*State]*State{&state1: &state1, &state2: &state2, &state3: &state3}
+	fa := FiniteAutomation{}
+	err := fa.InitializeFiniteAutomation(finiteStates, map[string]bool{"0": true}, &state1, []*State{&state2, &state3}, []TransitionFunction{tf1, tf2})
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	result, err := fa.Compute("0")
+
+	if err != nil {
+		t.Errorf("Expected nil error, got %v", err)
+	}
+
+	if *result != "1" {
+		t.Errorf("Expected %s, got %s", "1", *result)
+	}
+}
+
+// TestComputeWithContext_GuardsSameTarget tests that guarded transitions on the
+// same input and target are taken when either guard holds.
+func TestComputeWithContext_GuardsSameTarget(t *testing.T) {
+	state1, state2 := State{}, State{}
+	state1.Initialize("0", map[string]*State{})
+	state2.Initialize("1", map[string]*State{})
+
+	tf1 := TransitionFunction{}
+	tf1.Initialize(&state1, "0", &state2)
+	tf1.SetGuard(func(ctx Context) bool { return ctx["a"] == true })
+	tf2 := TransitionFunction{}
+	tf2.Initialize(&state1, "0", &state2) // <-- same state, input and target as tf1
+	tf2.SetGuard(func(ctx Context) bool { return ctx["b"] == true })
+
+	finiteStates := map[*State]*State{&state1: &state1, &state2: &state2}
+	fa := FiniteAutomation{}
+	err := fa.InitializeFiniteAutomation(finiteStates, map[string]bool{"0": true}, &state1, []*State{&state2}, []TransitionFunction{tf1, tf2})
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	for _, ctx := range []Context{{"a": true}, {"b": true}, {"a": true, "b": true}} {
+		if result, err := fa.ComputeWithContext("0", ctx); err != nil || *result != "1" {
+			t.Errorf("Expected %s for %v, got %v", "1", ctx, err)
+		}
+	}
+
+	if _, err := fa.ComputeWithContext("0", Context{}); err == nil {
+		t.Errorf("Expected error when no guard holds, got nil")
+	}
+}
+
+// TestComputeWithContext_ErrorNoGuardMatches tests that an error is returned
+// when no guard holds and there is no unguarded fallback.
+func TestComputeWithContext_ErrorNoGuardMatches(t *testing.T) {
+	fa := GetMockGuardedFiniteAutomation()
+
+	result, err := fa.ComputeWithContext("PP", Context{"amount": 100, "total": 100})
+
+	if err == nil {
+		t.Errorf("Expected error for invalid transition, got nil")
+	}
+
+	if result != nil {
+		t.Errorf("Expected nil result, got %s", *result)
+	}
+}
+
+func GetMockModuloThreeFiniteAutomation() FiniteAutomation {
+	state1, state2, state3 := State{}, State{}, State{}
+	state1.Initialize("0", map[string]*State{})
+	state2.Initialize("1", map[string]*State{})
+	state3.Initialize("2", map[string]*State{})
+
+	tf1, tf2, tf3 := TransitionFunction{}, TransitionFunction{}, TransitionFunction{}
+	tf4, tf5, tf6 := TransitionFunction{}, TransitionFunction{}, TransitionFunction{}
+	tf1.Initialize(&state1, "0", &state1)
+	tf2.Initialize(&state1, "1", &state2)
+	tf3.Initialize(&state2, "0", &state3)
+	tf4.Initialize(&state2, "1", &state1)
+	tf5.Initialize(&state3, "0", &state2)
+	tf6.Initialize(&state3, "1", &state3)
+	transitionFunctions := []TransitionFunction{tf1, tf2, tf3, tf4, tf5, tf6}
+
+	finiteStates := map[*State]*State{&state1: &state1, &state2: &state2, &state3: &state3}
+	acceptingStates := []*State{&state1, &state2, &state3}
+	inputs := map[string]bool{"0": true, "1": true}
+
+	fa := FiniteAutomation{}
+	fa.InitializeFiniteAutomation(finiteStates, inputs, &state1, acceptingStates, transitionFunctions)
+
+	return fa
+}
+
+// TestTransduce_NoError tests that Transduce returns the running remainder of
+// the modulo-three automaton, starting with the initial state.
+func TestTransduce_NoError(t *testing.T) {
+	fa := GetMockModuloThreeFiniteAutomation()
+
+	result, err := fa.Transduce("1001")
+
+	if err != nil {
+		t.Errorf("Expected nil error, got %v", err)
+	}
+
+	expected := []string{"0", "1", "2", "1", "0"}
+	if strings.Join(result, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+// TestTransduce_ErrorInvalidInput tests that Transduce returns an error when the
+// input string contains symbols not defined in the automaton's input set.
+func TestTransduce_ErrorInvalidInput(t *testing.T) {
+	fa := GetMockModuloThreeFiniteAutomation()
+
+	result, err := fa.Transduce("102")
+
+	if err == nil {
+		t.Errorf("Expected error for invalid input, got nil")
+	}
+
+	if result != nil {
+		t.Errorf("Expected nil result, got %v", result)
+	}
+}
+
+// TestTransduceWithContext_GuardTaken tests that the outputs follow the guarded
+// transitions taken for the context.
+func TestTransduceWithContext_GuardTaken(t *testing.T) {
+	fa := GetMockGuardedFiniteAutomation()
+
+	for amount, expected := range map[int][]string{
+		100: {"Pending", "Paid"},
+		40:  {"Pending", "PartiallyPaid"},
+	} {
+		result, err := fa.TransduceWithContext("P", Context{"amount": amount, "total": 100})
+
+		if err != nil {
+			t.Errorf("Expected nil error, got %v", err)
+		}
+
+		if strings.Join(result, ",") != strings.Join(expected, ",") {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	}
+}
+
+// TestComputeSymbols_NoError tests that multi-rune input symbols can be computed.
+func TestComputeSymbols_NoError(t *testing.T) {
+	fa := GetMockTokenFiniteAutomation()
+
+	result, err := fa.ComputeSymbols([]string{"DIGIT", "SEP", "DIGIT"})
+
+	if err != nil {
+		t.Errorf("Expected nil error, got %v", err)
+	}
+
+	if *result != "digits" {
+		t.Errorf("Expected %s, got %s", "digits", *result)
+	}
+
+	_, err = fa.ComputeSymbols([]string{"DIGIT", "DOT"})
+
+	if err == nil || !strings.Contains(err.Error(), "Invalid input") {
+		t.Errorf("Expected error for invalid input, got %v", err)
+	}
+}
@@ -0,0 +1,206 @@
+package models
+
+import (
+	"errors"
+	"unicode/utf8"
+)
+
+// positionKind is the operator of a PositionExpr
+type positionKind int
+
+const (
+	positionEmpty positionKind = iota
+	positionSymbols
+	positionConcat
+	positionUnion
+	positionStar
+	positionPlus
+	positionOptional
+)
+
+// PositionExpr is a regular expression over input symbols whose leaves are the
+// positions of the Glushkov construction.
+// It includes:
+//   - kind: the operator of the expression.
+//   - symbols: for a position, the input symbols it matches.
+//   - children: the operands of the expression.
+type PositionExpr struct {
+	kind     positionKind
+	symbols  []string
+	children []*PositionExpr
+}
+
+// Function to get the expression matching only the empty string
+func PositionEmpty() *PositionExpr {
+	return &PositionExpr{kind: positionEmpty}
+}
+
+// Function to get a position matching any one of the input symbols
+func PositionSymbols(symbols ...string) *PositionExpr {
+	return &PositionExpr{kind: positionSymbols, symbols: append([]string{}, symbols...)}
+}
+
+// Function to get the concatenation of one position per rune of the word
+func PositionLiteral(word string) *PositionExpr {
+	children := make([]*PositionExpr, 0, utf8.RuneCountInString(word))
+	for _, char := range word {
+		children = append(children, PositionSymbols(string(char)))
+	}
+
+	return PositionConcat(children...)
+}
+
+// Function to get the concatenation of the expressions
+//   - the concatenation of no expression matches the empty string
+func PositionConcat(exprs ...*PositionExpr) *PositionExpr {
+	return &PositionExpr{kind: positionConcat, children: exprs}
+}
+
+// Function to get the union of the expressions
+//   - the union of no expression matches nothing
+func PositionUnion(exprs ...*PositionExpr) *PositionExpr {
+	return &PositionExpr{kind: positionUnion, children: exprs}
+}
+
+// Function to get zero or more repetitions of the expression
+func PositionStar(expr *PositionExpr) *PositionExpr {
+	return &PositionExpr{kind: positionStar, children: []*PositionExpr{expr}}
+}
+
+// Function to get one or more repetitions of the expression
+func PositionPlus(expr *PositionExpr) *PositionExpr {
+	return &PositionExpr{kind: positionPlus, children: []*PositionExpr{expr}}
+}
+
+// Function to get zero or one occurrence of the expression
+func PositionOptional(expr *PositionExpr) *PositionExpr {
+	return &PositionExpr{kind: positionOptional, children: []*PositionExpr{expr}}
+}
+
+// Function to build the position NFA of the expression (Glushkov construction)
+//   - state 0 is the initial state and state i is the i-th position of the
+//     expression from the left, so the NFA has no epsilon transitions and one
+//     state per position plus the initial state, which BitParallel keeps out of
+//     its state mask
+//   - the inputs are the symbols of the positions
+//   - the accepting states output the given output
+//   - returns an error if the expression or one of its operands is nil
+func NewGlushkovNFA(expr *PositionExpr, output string) (*NFA, error) {
+	g := &glushkov{}
+	nullable, first, last, err := g.visit(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := map[string]bool{}
+	for _, position := range g.positions {
+		for _, symbol := range position.symbols {
+			inputs[symbol] = true
+		}
+	}
+
+	n := NewNFA(inputs)
+	n.AddState("", false)
+	for range g.positions {
+		n.AddState("", false)
+	}
+
+	addTransitions := func(from int, position int) {
+		for _, symbol := range g.positions[position].symbols {
+			n.AddTransition(from, symbol, position+1)
+		}
+	}
+
+	for _, position := range first {
+		addTransitions(0, position)
+	}
+	for from, follow := range g.follow {
+		for _, position := range follow {
+			addTransitions(from+1, position)
+		}
+	}
+
+	if nullable {
+		n.accepting[0], n.outputs[0] = true, output
+	}
+	for _, position := range last {
+		n.accepting[position+1], n.outputs[position+1] = true, output
+	}
+
+	return n, nil
+}
+
+// glushkov collects the positions of an expression and the positions that can
+// follow each of them
+type glushkov struct {
+	positions []*PositionExpr
+	follow    [][]int
+}
+
+// visit numbers the positions of the expression - returns whether it matches the
+// empty string, and the positions that can start and end its matches
+func (g *glushkov) visit(expr *PositionExpr) (bool, []int, []int, error) {
+	if expr == nil {
+		return false, nil, nil, errors.New("Invalid expression - nil operand")
+	}
+
+	switch expr.kind {
+	case positionSymbols:
+		g.positions = append(g.positions, expr)
+		g.follow = append(g.follow, nil)
+		position := len(g.positions) - 1
+		return false, []int{position}, []int{position}, nil
+	case positionConcat:
+		nullable, first, last := true, []int{}, []int{}
+		for _, child := range expr.children {
+			childNullable, childFirst, childLast, err := g.visit(child)
+			if err != nil {
+				return false, nil, nil, err
+			}
+
+			g.link(last, childFirst)
+			if nullable {
+				first = append(first, childFirst...)
+			}
+			if childNullable {
+				last = append(last, childLast...)
+			} else {
+				last = childLast
+			}
+			nullable = nullable && childNullable
+		}
+		return nullable, first, last, nil
+	case positionUnion:
+		nullable, first, last := false, []int{}, []int{}
+		for _, child := range expr.children {
+			childNullable, childFirst, childLast, err := g.visit(child)
+			if err != nil {
+				return false, nil, nil, err
+			}
+
+			nullable = nullable || childNullable
+			first = append(first, childFirst...)
+			last = append(last, childLast...)
+		}
+		return nullable, first, last, nil
+	case positionStar, positionPlus, positionOptional:
+		nullable, first, last, err := g.visit(expr.children[0])
+		if err != nil {
+			return false, nil, nil, err
+		}
+
+		if expr.kind != positionOptional {
+			g.link(last, first)
+		}
+		return nullable || expr.kind != positionPlus, first, last, nil
+	default:
+		return true, nil, nil, nil
+	}
+}
+
+// link records that every position of to can follow every position of from
+func (g *glushkov) link(from []int, to []int) {
+	for _, position := range from {
+		g.follow[position] = append(g.follow[position], to...)
+	}
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"regexp"
+	"testing"
+)
+
+// TestNewGlushkovNFA_Accepts tests that the position NFA accepts the language of
+// the expression with one state per position plus the initial state.
+func TestNewGlushkovNFA_Accepts(t *testing.T) {
+	bit := func() *PositionExpr { return PositionSymbols("0", "1") }
+
+	for _, tc := range []struct {
+		pattern   string
+		expr      *PositionExpr
+		positions int
+	}{
+		{"1+(01)*", PositionConcat(PositionPlus(PositionLiteral("1")), PositionStar(PositionLiteral("01"))), 3},
+		{"(0|1)*1(0|1)?", PositionConcat(PositionStar(bit()), PositionLiteral("1"), PositionOptional(bit())), 3},
+		{"0?1*0?", PositionConcat(PositionOptional(PositionLiteral("0")), PositionStar(PositionLiteral("1")), PositionOptional(PositionLiteral("0"))), 3},
+		{"(00|1)*0", PositionConcat(PositionStar(PositionUnion(PositionLiteral("00"), PositionLiteral("1"))), PositionLiteral("0")), 4},
+		{"((0|1)(0|1))*1", PositionConcat(PositionStar(PositionConcat(bit(), bit())), PositionLiteral("1")), 3},
+		{"", PositionEmpty(), 0},
+		{"(0|)1", PositionConcat(PositionUnion(PositionLiteral("0"), PositionConcat()), PositionLiteral("1")), 2},
+	} {
+		n, err := NewGlushkovNFA(tc.expr, tc.pattern)
+		if err != nil {
+			t.Fatalf("Expected nil error for %s, got %v", tc.pattern, err)
+		}
+
+		if n.NumStates() != tc.positions+1 {
+			t.Errorf("Expected %d states for %s, got %d", tc.positions+1, tc.pattern, n.NumStates())
+		}
+
+		re := regexp.MustCompile("^(" + tc.pattern + ")$")
+		for _, input := range binaryStrings(7) {
+			if n.Accepts(input) != re.MatchString(input) {
+				t.Errorf("Expected %t for %s and input %s", re.MatchString(input), tc.pattern, input)
+			}
+		}
+	}
+}
+
+// TestNewGlushkovNFA_EmptyUnion tests that the union of no expression matches nothing.
+func TestNewGlushkovNFA_EmptyUnion(t *testing.T) {
+	n, _ := NewGlushkovNFA(PositionStar(PositionUnion()), "")
+
+	if !n.Accepts("") || n.Accepts("0") {
+		t.Errorf("Expected only the empty string to be accepted")
+	}
+}
+
+// TestNewGlushkovNFA_ErrorNil tests that a nil operand is rejected.
+func TestNewGlushkovNFA_ErrorNil(t *testing.T) {
+	for _, expr := range []*PositionExpr{nil, PositionConcat(PositionLiteral("0"), nil), PositionStar(nil)} {
+		if _, err := NewGlushkovNFA(expr, ""); err == nil {
+			t.Errorf("Expected error for a nil operand, got nil")
+		}
+	}
+}
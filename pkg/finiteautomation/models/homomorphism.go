@@ -0,0 +1,108 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Function to build the homomorphic image of the automaton - accepts h(w) for
+// every w it accepts, h replacing every symbol by a sequence of symbols
+//   - every transition on a becomes a chain of transitions on the symbols of h(a),
+//     an empty sequence becomes an epsilon transition
+//   - the input symbols of the result are the symbols used by h
+//   - returns an error if h has no image for an input symbol
+func (fa *FiniteAutomation) MapSymbols(h map[string][]string) (*FiniteAutomation, error) {
+	na, err := fa.ToNFA()
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := map[string]bool{}
+	for input := range na.inputs {
+		image, ok := h[input]
+		if !ok {
+			return nil, errors.New(fmt.Sprintln("Invalid homomorphism - no image for input: ", input))
+		}
+
+		for _, symbol := range image {
+			inputs[symbol] = true
+		}
+	}
+
+	n := NewNFA(inputs)
+	for state := range na.outputs {
+		n.AddState(na.outputs[state], na.accepting[state])
+	}
+
+	for state := range na.outputs {
+		for input, targets := range na.transitions[state] {
+			image := h[input]
+			for _, target := range targets {
+				if len(image) == 0 {
+					n.AddEpsilonTransition(state, target)
+					continue
+				}
+
+				from := state
+				for _, symbol := range image[:len(image)-1] {
+					next := n.AddState("", false)
+					n.AddTransition(from, symbol, next)
+					from = next
+				}
+				n.AddTransition(from, image[len(image)-1], target)
+			}
+		}
+	}
+	n.initialState = na.initialState
+
+	return n.Determinize()
+}
+
+// Function to build the inverse homomorphic image of the automaton - accepts every
+// w over the symbols of h such that h(w) is accepted
+//   - the result keeps the states of the automaton, the transition on a leads to
+//     the state reached by reading h(a)
+//   - the input symbols of the result are the keys of h
+func (fa *FiniteAutomation) InverseMap(h map[string][]string) (*FiniteAutomation, error) {
+	if err := fa.checkUnguarded(); err != nil {
+		return nil, err
+	}
+
+	inputs := map[string]bool{}
+	for symbol := range h {
+		inputs[symbol] = true
+	}
+
+	copies := map[*State]*State{}
+	states := []*State{}
+	acceptingStates := []*State{}
+	for _, state := range fa.orderedStates() {
+		copies[state] = newState(state.output)
+		states = append(states, copies[state])
+		if fa.acceptingStates[state] {
+			acceptingStates = append(acceptingStates, copies[state])
+		}
+	}
+
+	transitionFunctions := []TransitionFunction{}
+	for _, state := range fa.orderedStates() {
+		for symbol, image := range h {
+			target, ok := state, true
+			for _, input := range image {
+				if target, ok = target.transition[input]; !ok {
+					break
+				}
+			}
+
+			if !ok {
+				continue
+			}
+
+			tf := TransitionFunction{}
+			tf.Initialize(copies[state], symbol, copies[target])
+			transitionFunctions = append(transitionFunctions, tf)
+		}
+	}
+
+	return newFiniteAutomation(states, inputs, copies[fa.initialState], acceptingStates, transitionFunctions)
+}
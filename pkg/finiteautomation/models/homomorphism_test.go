@@ -0,0 +1,63 @@
+package models
+
+import "testing"
+
+// GetMockTokenFiniteAutomation returns an automaton over the abstract tokens
+// DIGIT and SEP accepting DIGIT+ (SEP DIGIT+)*.
+func GetMockTokenFiniteAutomation() *FiniteAutomation {
+	start, digits, separator := newState("start"), newState("digits"), newState("separator")
+
+	tf1, tf2, tf3, tf4 := TransitionFunction{}, TransitionFunction{}, TransitionFunction{}, TransitionFunction{}
+	tf1.Initialize(start, "DIGIT", digits)
+	tf2.Initialize(digits, "DIGIT", digits)
+	tf3.Initialize(digits, "SEP", separator)
+	tf4.Initialize(separator, "DIGIT", digits)
+
+	fa, _ := newFiniteAutomation([]*State{start, digits, separator}, map[string]bool{"DIGIT": true, "SEP": true}, start, []*State{digits}, []TransitionFunction{tf1, tf2, tf3, tf4})
+
+	return fa
+}
+
+// TestMapSymbols_NoError tests that abstract tokens are lifted to concrete characters.
+func TestMapSymbols_NoError(t *testing.T) {
+	fa := GetMockTokenFiniteAutomation()
+
+	image, err := fa.MapSymbols(map[string][]string{"DIGIT": {"1"}, "SEP": {"0", "0"}})
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	assertLanguage(t, image, "1+(001+)*")
+}
+
+// TestMapSymbols_ErrorMissingImage tests that every input symbol needs an image.
+func TestMapSymbols_ErrorMissingImage(t *testing.T) {
+	fa := GetMockTokenFiniteAutomation()
+
+	_, err := fa.MapSymbols(map[string][]string{"DIGIT": {"1"}})
+
+	if err == nil {
+		t.Errorf("Expected error for missing image, got nil")
+	}
+}
+
+// TestInverseMap_NoError tests that concrete characters are mapped back to
+// abstract tokens.
+func TestInverseMap_NoError(t *testing.T) {
+	concrete := GetMockRegexFiniteAutomation("1+(001+)*")
+	tokens := GetMockTokenFiniteAutomation()
+
+	preimage, err := concrete.InverseMap(map[string][]string{"DIGIT": {"1"}, "SEP": {"0", "0"}})
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	for _, input := range [][]string{{"DIGIT"}, {"DIGIT", "SEP", "DIGIT", "DIGIT"}, {"SEP"}, {"DIGIT", "SEP"}, {}} {
+		_, expected := tokens.ComputeSymbols(input)
+		_, err := preimage.ComputeSymbols(input)
+
+		if (err == nil) != (expected == nil) {
+			t.Errorf("Expected %t for input %v", expected == nil, input)
+		}
+	}
+}
@@ -0,0 +1,289 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// KeywordAutomaton is an Aho–Corasick automaton for a set of keywords, kept as a
+// sparse trie with failure links so its size grows with the total length of the
+// keywords, not with the size of their alphabet.
+// It contains:
+//   - keywords: the keywords, in the order they were given.
+//   - children: the trie edges of every node, node 0 is the root.
+//   - fail: the failure link of every node, the node of its longest proper suffix
+//     in the trie.
+//   - keyword: the id of the keyword ending at every node, -1 for none.
+//   - dictionary: for every node, the nearest node on its failure chain where a
+//     keyword ends, -1 for none.
+//   - depth: the length in runes of the prefix of every node.
+//   - maxLength: the length in runes of the longest keyword.
+//   - automaton: the complete DFA, built on demand by ToFiniteAutomation, with
+//     the node of every one of its states.
+type KeywordAutomaton struct {
+	keywords      []string
+	children      []map[rune]int32
+	fail          []int32
+	keyword       []int32
+	dictionary    []int32
+	depth         []int32
+	maxLength     int
+	automatonOnce sync.Once
+	automaton     *FiniteAutomation
+	automatonErr  error
+	nodes         map[*State]int32
+}
+
+// KeywordMatch represents an occurrence of a keyword in a text.
+// It contains:
+//   - Keyword: the keyword found.
+//   - Start: the byte offset where the occurrence starts.
+//   - End: the byte offset right after the occurrence.
+type KeywordMatch struct {
+	Keyword string
+	Start   int
+	End     int
+}
+
+// Function to build the Aho–Corasick automaton of the keywords
+//   - builds the trie, then the failure and dictionary links in breadth-first order
+//   - the automaton accepts the strings that end with one of the keywords, runes
+//     outside the keywords lead back to the root
+//   - returns an error for an empty list or an empty keyword, duplicates are ignored
+func NewKeywordAutomaton(keywords []string) (*KeywordAutomaton, error) {
+	if len(keywords) == 0 {
+		return nil, errors.New(fmt.Sprintln("Invalid keywords - no keyword given"))
+	}
+
+	k := &KeywordAutomaton{keywords: keywords, children: []map[rune]int32{nil}, keyword: []int32{-1}, depth: []int32{0}}
+	for id, keyword := range keywords {
+		if keyword == "" {
+			return nil, errors.New(fmt.Sprintln("Invalid keywords - empty keyword at index: ", id))
+		}
+
+		node := int32(0)
+		for _, char := range keyword {
+			child, ok := k.children[node][char]
+			if !ok {
+				child = int32(len(k.children))
+				k.children = append(k.children, nil)
+				k.keyword = append(k.keyword, -1)
+				k.depth = append(k.depth, k.depth[node]+1)
+				if k.children[node] == nil {
+					k.children[node] = map[rune]int32{}
+				}
+				k.children[node][char] = child
+			}
+			node = child
+		}
+
+		if k.keyword[node] < 0 {
+			k.keyword[node] = int32(id)
+		}
+		if int(k.depth[node]) > k.maxLength {
+			k.maxLength = int(k.depth[node])
+		}
+	}
+
+	// Breadth-first order guarantees the failure target of a node is complete
+	// before the node itself.
+	k.fail = make([]int32, len(k.children))
+	k.dictionary = make([]int32, len(k.children))
+	k.dictionary[0] = -1
+	order := []int32{0}
+	for i := 0; i < len(order); i++ {
+		node := order[i]
+		for char, child := range k.children[node] {
+			if node != 0 {
+				k.fail[child] = k.next(k.fail[node], char)
+			}
+
+			if fail := k.fail[child]; k.keyword[fail] >= 0 {
+				k.dictionary[child] = fail
+			} else {
+				k.dictionary[child] = k.dictionary[fail]
+			}
+			order = append(order, child)
+		}
+	}
+
+	return k, nil
+}
+
+// next returns the node reached from the node on the rune, following failure
+// links until a trie edge matches, the root for runes outside the keywords
+func (k *KeywordAutomaton) next(node int32, char rune) int32 {
+	for {
+		if child, ok := k.children[node][char]; ok {
+			return child
+		}
+
+		if node == 0 {
+			return 0
+		}
+		node = k.fail[node]
+	}
+}
+
+// run feeds the input through the automaton and returns the node it ends in
+func (k *KeywordAutomaton) run(input string) int32 {
+	node := int32(0)
+	for _, char := range input {
+		node = k.next(node, char)
+	}
+
+	return node
+}
+
+// matchedKeywords returns the ids of the keywords ending at the node, longest first
+func (k *KeywordAutomaton) matchedKeywords(node int32) []int32 {
+	ids := []int32{}
+	if k.keyword[node] < 0 {
+		node = k.dictionary[node]
+	}
+
+	for ; node >= 0; node = k.dictionary[node] {
+		ids = append(ids, k.keyword[node])
+	}
+
+	return ids
+}
+
+// output returns the keywords ending at the node joined by commas, longest first
+func (k *KeywordAutomaton) output(node int32) string {
+	keywords := []string{}
+	for _, id := range k.matchedKeywords(node) {
+		keywords = append(keywords, k.keywords[id])
+	}
+
+	return strings.Join(keywords, ",")
+}
+
+// Function to compute the keywords the input ends with - returns them joined by
+// commas, longest first
+//   - runes outside the keywords lead back to the root instead of being rejected
+//   - returns an error if the input does not end with a keyword
+func (k *KeywordAutomaton) Compute(input string) (*string, error) {
+	node := k.run(input)
+	if k.keyword[node] < 0 && k.dictionary[node] < 0 {
+		return nil, errors.New(fmt.Sprintln("Invalid final state - not in the list of accepting state -", ""))
+	}
+
+	result := k.output(node)
+
+	return &result, nil
+}
+
+// Function to check if the input ends with one of the keywords
+func (k *KeywordAutomaton) Match(input string) bool {
+	node := k.run(input)
+
+	return k.keyword[node] >= 0 || k.dictionary[node] >= 0
+}
+
+// Function to get the automaton as a complete DFA over the runes of the keywords,
+// for compilation, export or the algorithms of FiniteAutomation
+//   - built on the first call, the size of its transition function is the number
+//     of trie nodes times the number of distinct runes
+//   - a state is accepting when a keyword ends there and then outputs these
+//     keywords joined by commas, longest first, other states output nothing
+//   - its inputs are only the runes of the keywords, use Compute, Match or Scan on
+//     texts with other runes
+func (k *KeywordAutomaton) ToFiniteAutomation() (*FiniteAutomation, error) {
+	k.automatonOnce.Do(func() {
+		alphabet := map[rune]bool{}
+		for _, children := range k.children {
+			for char := range children {
+				alphabet[char] = true
+			}
+		}
+
+		runes := make([]rune, 0, len(alphabet))
+		inputs := make(map[string]bool, len(alphabet))
+		for char := range alphabet {
+			runes = append(runes, char)
+			inputs[string(char)] = true
+		}
+		sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+
+		states := make([]*State, len(k.children))
+		acceptingStates := []*State{}
+		k.nodes = make(map[*State]int32, len(k.children))
+		for node := range k.children {
+			states[node] = newState(k.output(int32(node)))
+			k.nodes[states[node]] = int32(node)
+			if k.keyword[node] >= 0 || k.dictionary[node] >= 0 {
+				acceptingStates = append(acceptingStates, states[node])
+			}
+		}
+
+		transitionFunctions := make([]TransitionFunction, 0, len(k.children)*len(runes))
+		for node := range k.children {
+			for _, char := range runes {
+				tf := TransitionFunction{}
+				tf.Initialize(states[node], string(char), states[k.next(int32(node), char)])
+				transitionFunctions = append(transitionFunctions, tf)
+			}
+		}
+
+		k.automaton, k.automatonErr = newFiniteAutomation(states, inputs, states[0], acceptingStates, transitionFunctions)
+	})
+
+	return k.automaton, k.automatonErr
+}
+
+// Function to list the keywords ending at a state of ToFiniteAutomation, longest first
+func (k *KeywordAutomaton) MatchedKeywords(state *State) []string {
+	keywords := []string{}
+	node, ok := k.nodes[state]
+	if !ok {
+		return keywords
+	}
+
+	for _, id := range k.matchedKeywords(node) {
+		keywords = append(keywords, k.keywords[id])
+	}
+
+	return keywords
+}
+
+// Function to find every occurrence of every keyword in the text, overlaps included
+//   - occurrences are ordered by end offset, longest first for the same end
+//   - runes outside the keyword alphabet reset the automaton to its initial state
+func (k *KeywordAutomaton) Scan(text string) []KeywordMatch {
+	var matches []KeywordMatch
+
+	// starts holds the byte offsets of the last maxLength runes read, so
+	// occurrences start at the offset of their first rune even when invalid bytes
+	// were read as U+FFFD.
+	starts := make([]int, k.maxLength)
+	read := 0
+	node := int32(0)
+	for end := 0; end < len(text); {
+		char, size := utf8.DecodeRuneInString(text[end:])
+		starts[read%k.maxLength] = end
+		read++
+		end += size
+
+		node = k.next(node, char)
+		match := node
+		if k.keyword[match] < 0 {
+			match = k.dictionary[match]
+		}
+
+		for ; match >= 0; match = k.dictionary[match] {
+			start := starts[(read-int(k.depth[match]))%k.maxLength]
+			matches = append(matches, KeywordMatch{Keyword: k.keywords[k.keyword[match]], Start: start, End: end})
+		}
+	}
+
+	return matches
+}
+
+func (k *KeywordAutomaton) GetKeywords() []string {
+	return k.keywords
+}
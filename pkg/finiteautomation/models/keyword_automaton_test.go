@@ -0,0 +1,168 @@
+package models
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// TestNewKeywordAutomaton_Scan tests that every occurrence of every keyword is
+// found, overlaps included.
+func TestNewKeywordAutomaton_Scan(t *testing.T) {
+	k, err := NewKeywordAutomaton([]string{"he", "she", "his", "hers"})
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	matches := k.Scan("ushers, his")
+
+	expected := []KeywordMatch{
+		{Keyword: "she", Start: 1, End: 4},
+		{Keyword: "he", Start: 2, End: 4},
+		{Keyword: "hers", Start: 2, End: 6},
+		{Keyword: "his", Start: 8, End: 11},
+	}
+	if !reflect.DeepEqual(matches, expected) {
+		t.Errorf("Expected %v, got %v", expected, matches)
+	}
+}
+
+// TestNewKeywordAutomaton_ScanInvalidUTF8 tests that occurrences over an invalid
+// byte end after the byte read.
+func TestNewKeywordAutomaton_ScanInvalidUTF8(t *testing.T) {
+	k, _ := NewKeywordAutomaton([]string{"a\uFFFD"})
+
+	matches := k.Scan("xa\xffb")
+
+	expected := []KeywordMatch{{Keyword: "a\uFFFD", Start: 1, End: 3}}
+	if !reflect.DeepEqual(matches, expected) {
+		t.Errorf("Expected %v, got %v", expected, matches)
+	}
+}
+
+// TestNewKeywordAutomaton_Compute tests that the automaton accepts the strings
+// ending with a keyword, like its FiniteAutomation and compiled forms.
+func TestNewKeywordAutomaton_Compute(t *testing.T) {
+	k, err := NewKeywordAutomaton([]string{"he", "she"})
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	result, err := k.Compute("hshe")
+
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	if *result != "she,he" {
+		t.Errorf("Expected %s, got %s", "she,he", *result)
+	}
+
+	fa, err := k.ToFiniteAutomation()
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	if result, err := fa.Compute("hshe"); err != nil || *result != "she,he" {
+		t.Errorf("Expected %s from the FiniteAutomation, got %v", "she,he", err)
+	}
+
+	if !fa.Compile().Match("hshe") {
+		t.Errorf("Expected compiled automaton to accept")
+	}
+
+	_, err = k.Compute("hes")
+
+	if err == nil {
+		t.Errorf("Expected error for invalid final state, got nil")
+	}
+}
+
+// TestNewKeywordAutomaton_UnknownRunes tests that runes outside the keywords
+// lead back to the root instead of being rejected.
+func TestNewKeywordAutomaton_UnknownRunes(t *testing.T) {
+	k, _ := NewKeywordAutomaton([]string{"he", "she"})
+
+	result, err := k.Compute("é she")
+
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	if *result != "she,he" {
+		t.Errorf("Expected %s, got %s", "she,he", *result)
+	}
+
+	if !k.Match("ésé he") || k.Match("sh é") || k.Match("hé") {
+		t.Errorf("Expected only inputs ending with a keyword to match")
+	}
+
+	if k.automaton != nil {
+		t.Errorf("Expected the complete DFA to be built only on demand")
+	}
+}
+
+// TestNewKeywordAutomaton_ComputeSuffixKeyword tests that the output names the
+// keyword matched, not the trie prefix read.
+func TestNewKeywordAutomaton_ComputeSuffixKeyword(t *testing.T) {
+	k, _ := NewKeywordAutomaton([]string{"abc", "b"})
+
+	result, err := k.Compute("ab")
+
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	if *result != "b" {
+		t.Errorf("Expected %s, got %s", "b", *result)
+	}
+}
+
+// TestNewKeywordAutomaton_MatchedKeywords tests that a state reports every
+// keyword ending there.
+func TestNewKeywordAutomaton_MatchedKeywords(t *testing.T) {
+	k, _ := NewKeywordAutomaton([]string{"he", "she"})
+
+	fa, _ := k.ToFiniteAutomation()
+	matches := fa.FindAll("she", MatchLeftmostLongest)
+
+	if len(matches) != 1 || !reflect.DeepEqual(k.MatchedKeywords(matches[0].State), []string{"she", "he"}) {
+		t.Errorf("Expected one match of she and he, got %v", matches)
+	}
+}
+
+// TestNewKeywordAutomaton_ScanMatchesNaive tests that Scan reports the
+// occurrences found by comparing every keyword at every offset.
+func TestNewKeywordAutomaton_ScanMatchesNaive(t *testing.T) {
+	keywords := []string{"a", "ab", "bab", "bc", "bca", "c", "caa", "€b", "ü"}
+	k, _ := NewKeywordAutomaton(keywords)
+
+	text := "abccab€bcaabüab€€babcaab"
+	expected := []KeywordMatch{}
+	for end := 1; end <= len(text); end++ {
+		for _, keyword := range keywords {
+			if start := end - len(keyword); start >= 0 && text[start:end] == keyword {
+				expected = append(expected, KeywordMatch{Keyword: keyword, Start: start, End: end})
+			}
+		}
+	}
+	sort.SliceStable(expected, func(i, j int) bool {
+		if expected[i].End != expected[j].End {
+			return expected[i].End < expected[j].End
+		}
+		return expected[i].Start < expected[j].Start
+	})
+
+	if matches := k.Scan(text); !reflect.DeepEqual(matches, expected) {
+		t.Errorf("Expected %v, got %v", expected, matches)
+	}
+}
+
+// TestNewKeywordAutomaton_ErrorEmptyKeyword tests that empty keywords are rejected.
+func TestNewKeywordAutomaton_ErrorEmptyKeyword(t *testing.T) {
+	_, err := NewKeywordAutomaton([]string{"a", ""})
+
+	if err == nil {
+		t.Errorf("Expected error for empty keyword, got nil")
+	}
+}
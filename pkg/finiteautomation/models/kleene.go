@@ -0,0 +1,225 @@
+package models
+
+// Function to build the concatenation of two automata - accepts xy for every x
+// accepted by a and y accepted by b
+//   - built as an NFA joining the accepting states of a to the initial state of b,
+//     then determinized
+func Concat(a *FiniteAutomation, b *FiniteAutomation) (*FiniteAutomation, error) {
+	na, err := a.ToNFA()
+	if err != nil {
+		return nil, err
+	}
+
+	nb, err := b.ToNFA()
+	if err != nil {
+		return nil, err
+	}
+
+	n := NewNFA(nil)
+	offsetA := n.include(na)
+	offsetB := n.include(nb)
+	for state := range na.outputs {
+		if na.accepting[state] {
+			n.accepting[offsetA+state] = false
+			n.AddEpsilonTransition(offsetA+state, offsetB+nb.initialState)
+		}
+	}
+	n.initialState = offsetA + na.initialState
+
+	return n.Determinize()
+}
+
+// Function to build the Kleene star of an automaton - accepts the concatenations
+// of zero or more words accepted by a
+//   - a new accepting initial state is added, the accepting states loop back to it
+func Star(a *FiniteAutomation) (*FiniteAutomation, error) {
+	return repeat(a, true)
+}
+
+// Function to build the Kleene plus of an automaton - accepts the concatenations
+// of one or more words accepted by a
+func Plus(a *FiniteAutomation) (*FiniteAutomation, error) {
+	return repeat(a, false)
+}
+
+// repeat builds the star of the automaton, or its plus when the empty word is
+// not to be accepted
+func repeat(a *FiniteAutomation, acceptEmpty bool) (*FiniteAutomation, error) {
+	na, err := a.ToNFA()
+	if err != nil {
+		return nil, err
+	}
+
+	n := NewNFA(nil)
+	start := n.AddState(na.outputs[na.initialState], acceptEmpty)
+	offset := n.include(na)
+	n.AddEpsilonTransition(start, offset+na.initialState)
+	for state := range na.outputs {
+		if na.accepting[state] {
+			n.AddEpsilonTransition(offset+state, start)
+		}
+	}
+	n.initialState = start
+
+	return n.Determinize()
+}
+
+// Function to build the reversal of an automaton - accepts the words accepted by
+// a read backwards
+//   - every transition is reversed, a new initial state leads to the former
+//     accepting states and the former initial state becomes the only accepting one
+func Reverse(a *FiniteAutomation) (*FiniteAutomation, error) {
+	na, err := a.ToNFA()
+	if err != nil {
+		return nil, err
+	}
+
+	n := NewNFA(na.inputs)
+	start := n.AddState("", false)
+	offset := len(n.outputs)
+	for state := range na.outputs {
+		n.AddState(na.outputs[state], state == na.initialState)
+	}
+
+	for state := range na.outputs {
+		for input, targets := range na.transitions[state] {
+			for _, target := range targets {
+				n.AddTransition(offset+target, input, offset+state)
+			}
+		}
+
+		if na.accepting[state] {
+			n.AddEpsilonTransition(start, offset+state)
+		}
+	}
+	n.initialState = start
+
+	return n.Determinize()
+}
+
+// Function to build the left quotient of a by b - accepts every y such that xy is
+// accepted by a for some x accepted by b
+//   - the new initial states are the states of a reached by the words of b
+func LeftQuotient(a *FiniteAutomation, b *FiniteAutomation) (*FiniteAutomation, error) {
+	na, err := a.ToNFA()
+	if err != nil {
+		return nil, err
+	}
+
+	nb, err := b.ToNFA()
+	if err != nil {
+		return nil, err
+	}
+
+	// Forward search over the pairs (state of a, state of b) from both initial
+	// states, keeping the states of a paired with an accepting state of b.
+	reached := map[int]bool{}
+	for pair := range productReachable(na, nb, [][2]int{{na.initialState, nb.initialState}}, false) {
+		if nb.accepting[pair[1]] {
+			reached[pair[0]] = true
+		}
+	}
+
+	n := NewNFA(nil)
+	start := n.AddState("", false)
+	offset := n.include(na)
+	for state := range reached {
+		n.AddEpsilonTransition(start, offset+state)
+	}
+	n.initialState = start
+
+	return n.Determinize()
+}
+
+// Function to build the right quotient of a by b - accepts every x such that xy is
+// accepted by a for some y accepted by b
+//   - the new accepting states are the states of a from which a word of b leads
+//     to an accepting state of a
+func RightQuotient(a *FiniteAutomation, b *FiniteAutomation) (*FiniteAutomation, error) {
+	na, err := a.ToNFA()
+	if err != nil {
+		return nil, err
+	}
+
+	nb, err := b.ToNFA()
+	if err != nil {
+		return nil, err
+	}
+
+	// Backward search over the pairs from every pair of accepting states, keeping
+	// the states of a paired with the initial state of b.
+	accepting := [][2]int{}
+	for sa := range na.outputs {
+		for sb := range nb.outputs {
+			if na.accepting[sa] && nb.accepting[sb] {
+				accepting = append(accepting, [2]int{sa, sb})
+			}
+		}
+	}
+
+	for state := range na.accepting {
+		na.accepting[state] = false
+	}
+	for pair := range productReachable(na, nb, accepting, true) {
+		if pair[1] == nb.initialState {
+			na.accepting[pair[0]] = true
+		}
+	}
+
+	return na.Determinize()
+}
+
+// productReachable returns the pairs of states of two NFAs without epsilon
+// transitions reachable from the start pairs, reading the same symbol in both,
+// forwards or backwards
+func productReachable(a *NFA, b *NFA, start [][2]int, backwards bool) map[[2]int]bool {
+	reversedA, reversedB := a.transitions, b.transitions
+	if backwards {
+		reversedA, reversedB = a.reversedTransitions(), b.reversedTransitions()
+	}
+
+	seen := map[[2]int]bool{}
+	queue := [][2]int{}
+	for _, pair := range start {
+		if !seen[pair] {
+			seen[pair] = true
+			queue = append(queue, pair)
+		}
+	}
+
+	for len(queue) > 0 {
+		pair := queue[0]
+		queue = queue[1:]
+		for input, targetsA := range reversedA[pair[0]] {
+			for _, targetA := range targetsA {
+				for _, targetB := range reversedB[pair[1]][input] {
+					next := [2]int{targetA, targetB}
+					if !seen[next] {
+						seen[next] = true
+						queue = append(queue, next)
+					}
+				}
+			}
+		}
+	}
+
+	return seen
+}
+
+// reversedTransitions returns the transitions of the NFA with every edge reversed
+func (n *NFA) reversedTransitions() []map[string][]int {
+	reversed := make([]map[string][]int, len(n.outputs))
+	for state := range reversed {
+		reversed[state] = map[string][]int{}
+	}
+
+	for state, transitions := range n.transitions {
+		for input, targets := range transitions {
+			for _, target := range targets {
+				reversed[target][input] = append(reversed[target][input], state)
+			}
+		}
+	}
+
+	return reversed
+}
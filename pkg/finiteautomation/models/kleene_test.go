@@ -0,0 +1,92 @@
+package models
+
+import (
+	"regexp"
+	"testing"
+)
+
+// GetMockRegexFiniteAutomation returns the determinized automaton of the pattern.
+func GetMockRegexFiniteAutomation(pattern string) *FiniteAutomation {
+	n, _ := CompileRegex(pattern)
+	fa, _ := n.Determinize()
+
+	return fa
+}
+
+// assertLanguage checks the automaton accepts the binary strings up to length 7
+// matching the pattern, and only them.
+func assertLanguage(t *testing.T, fa *FiniteAutomation, pattern string) {
+	re := regexp.MustCompile("^(" + pattern + ")$")
+	for _, input := range binaryStrings(7) {
+		_, err := fa.Compute(input)
+
+		if (err == nil) != re.MatchString(input) {
+			t.Errorf("Expected %t for input %s and language %s", re.MatchString(input), input, pattern)
+		}
+	}
+}
+
+// TestConcat_NoError tests the concatenation of two automata.
+func TestConcat_NoError(t *testing.T) {
+	fa, err := Concat(GetMockRegexFiniteAutomation("1+"), GetMockRegexFiniteAutomation("(01)*"))
+
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	assertLanguage(t, fa, "1+(01)*")
+}
+
+// TestStar_NoError tests the Kleene star and plus of an automaton.
+func TestStar_NoError(t *testing.T) {
+	star, err := Star(GetMockRegexFiniteAutomation("01|1"))
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	assertLanguage(t, star, "(01|1)*")
+
+	plus, err := Plus(GetMockRegexFiniteAutomation("01|1"))
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	assertLanguage(t, plus, "(01|1)+")
+}
+
+// TestReverse_NoError tests the reversal of an automaton.
+func TestReverse_NoError(t *testing.T) {
+	fa, err := Reverse(GetMockRegexFiniteAutomation("0*1(10)*"))
+
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	assertLanguage(t, fa, "(01)*10*")
+}
+
+// TestQuotients_NoError tests the left and right quotients.
+func TestQuotients_NoError(t *testing.T) {
+	a := GetMockRegexFiniteAutomation("(01)*")
+
+	left, err := LeftQuotient(a, GetMockRegexFiniteAutomation("0"))
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	assertLanguage(t, left, "1(01)*")
+
+	right, err := RightQuotient(a, GetMockRegexFiniteAutomation("1|011"))
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	assertLanguage(t, right, "(01)*0")
+}
+
+// TestConcat_ErrorGuarded tests that guarded transitions are rejected.
+func TestConcat_ErrorGuarded(t *testing.T) {
+	fa := GetMockGuardedFiniteAutomation()
+
+	_, err := Concat(&fa, &fa)
+
+	if err == nil {
+		t.Errorf("Expected error for guarded transition, got nil")
+	}
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Function to build an NFA accepting every string within maxDistance edits
+// (insertions, deletions, substitutions) of the word
+//   - state (i, e) means i runes of the word are consumed with e edits, it gets id
+//     i*(maxDistance+1)+e
+//   - the runes of the word are added to the alphabet, a nil alphabet means the
+//     runes of the word only
+//   - accepting states output their number of edits; as lower ids come first,
+//     the determinized automaton outputs the edit distance of the input
+//   - use Accepts for lazy simulation or Determinize for a FiniteAutomation
+func NewLevenshteinAutomaton(word string, maxDistance int, alphabet map[string]bool) (*NFA, error) {
+	if maxDistance < 0 {
+		return nil, errors.New(fmt.Sprintln("Invalid max distance: ", maxDistance))
+	}
+
+	runes := []rune(word)
+	n := NewNFA(alphabet)
+	for _, char := range runes {
+		n.inputs[string(char)] = true
+	}
+
+	id := func(i, e int) int {
+		return i*(maxDistance+1) + e
+	}
+
+	for i := 0; i <= len(runes); i++ {
+		for e := 0; e <= maxDistance; e++ {
+			if i == len(runes) {
+				n.AddState(strconv.Itoa(e), true)
+			} else {
+				n.AddState("", false)
+			}
+		}
+	}
+
+	for i := 0; i <= len(runes); i++ {
+		for e := 0; e <= maxDistance; e++ {
+			if i < len(runes) {
+				n.AddTransition(id(i, e), string(runes[i]), id(i+1, e))
+			}
+
+			if e == maxDistance {
+				continue
+			}
+
+			for input := range n.inputs {
+				n.AddTransition(id(i, e), input, id(i, e+1))
+				if i < len(runes) && input != string(runes[i]) {
+					n.AddTransition(id(i, e), input, id(i+1, e+1))
+				}
+			}
+
+			if i < len(runes) {
+				n.AddEpsilonTransition(id(i, e), id(i+1, e+1))
+			}
+		}
+	}
+
+	return n, nil
+}
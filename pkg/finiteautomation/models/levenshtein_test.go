@@ -0,0 +1,85 @@
+package models
+
+import "testing"
+
+// editDistance returns the Levenshtein distance between two strings.
+func editDistance(a string, b string) int {
+	x, y := []rune(a), []rune(b)
+	previous := make([]int, len(y)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(x); i++ {
+		current := make([]int, len(y)+1)
+		current[0] = i
+		for j := 1; j <= len(y); j++ {
+			cost := 1
+			if x[i-1] == y[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+
+	return previous[len(y)]
+}
+
+// TestLevenshteinAutomaton_Accepts tests that the NFA accepts exactly the strings
+// within the maximum distance.
+func TestLevenshteinAutomaton_Accepts(t *testing.T) {
+	alphabet := map[string]bool{"0": true, "1": true}
+	n, err := NewLevenshteinAutomaton("0110", 1, alphabet)
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	for _, input := range binaryStrings(6) {
+		if n.Accepts(input) != (editDistance("0110", input) <= 1) {
+			t.Errorf("Expected %t for input %s", editDistance("0110", input) <= 1, input)
+		}
+	}
+}
+
+// TestLevenshteinAutomaton_Determinize tests that the determinized automaton
+// outputs the edit distance.
+func TestLevenshteinAutomaton_Determinize(t *testing.T) {
+	n, err := NewLevenshteinAutomaton("kitten", 3, map[string]bool{"s": true, "i": true, "g": true})
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	fa, err := n.Determinize()
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	for input, expected := range map[string]string{"kitten": "0", "sitten": "1", "sitting": "3", "kiten": "1"} {
+		result, err := fa.Compute(input)
+
+		if err != nil {
+			t.Errorf("Expected nil error for %s, got %v", input, err)
+			continue
+		}
+
+		if *result != expected {
+			t.Errorf("Expected %s, got %s for input %s", expected, *result, input)
+		}
+	}
+
+	_, err = fa.Compute("sittinggg")
+
+	if err == nil {
+		t.Errorf("Expected error for input too far from the word, got nil")
+	}
+}
+
+// TestLevenshteinAutomaton_ErrorNegativeDistance tests that negative distances are rejected.
+func TestLevenshteinAutomaton_ErrorNegativeDistance(t *testing.T) {
+	_, err := NewLevenshteinAutomaton("word", -1, nil)
+
+	if err == nil {
+		t.Errorf("Expected error for negative distance, got nil")
+	}
+}
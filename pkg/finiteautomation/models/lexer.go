@@ -0,0 +1,227 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// LexerRule defines one kind of token.
+// It contains:
+//   - Name: the kind of the tokens produced by the rule.
+//   - Pattern: a regular expression, see CompileRegex, or
+//   - Automaton: an automaton accepting the tokens, used when Pattern is empty.
+//   - Skip: tokens of this kind are matched but not returned, e.g. whitespace.
+type LexerRule struct {
+	Name      string
+	Pattern   string
+	Automaton *FiniteAutomation
+	Skip      bool
+}
+
+// Token represents a token found by a Lexer.
+// It contains:
+//   - Kind: the name of the rule that produced the token.
+//   - Text: the matched text.
+//   - Offset: the byte offset of the token in the input.
+type Token struct {
+	Kind   string
+	Text   string
+	Offset int
+}
+
+// Lexer splits inputs into tokens using maximal munch.
+// It contains:
+//   - automaton: the DFA of all rules, every accepting state outputs the name of
+//     the rule with the highest priority accepting there.
+//   - skip: the names of the rules whose tokens are dropped.
+//   - boundaries: the sorted first runes of the rune intervals no rule tells
+//     apart, the input symbol of an interval is its first rune.
+type Lexer struct {
+	automaton  *FiniteAutomation
+	skip       map[string]bool
+	boundaries []rune
+}
+
+// Function to build a Lexer from an ordered list of rules
+//   - the rules are joined into one NFA and determinized, so every state knows all
+//     rules that still match
+//   - when several rules accept the same text, the rule listed first wins
+//   - the runes are split into intervals at the bounds of the classes and at the
+//     runes of the rules, and each interval is one input symbol, so '.' and
+//     negated classes also match runes appearing in no rule
+//   - returns an error for a rule without name, pattern or automaton, an invalid
+//     pattern, or a rule accepting the empty string
+func NewLexer(rules []LexerRule) (*Lexer, error) {
+	if len(rules) == 0 {
+		return nil, errors.New(fmt.Sprintln("Invalid lexer - no rule given"))
+	}
+
+	alphabet := defaultRegexAlphabet()
+	boundaries := map[rune]bool{0: true, 0xd800: true, 0xe000: true}
+	patterns := make([]*regexNode, len(rules))
+	automata := make([]*NFA, len(rules))
+	for i, rule := range rules {
+		if rule.Name == "" {
+			return nil, errors.New(fmt.Sprintln("Invalid lexer rule - empty name at index: ", i))
+		}
+
+		switch {
+		case rule.Pattern != "":
+			node, err := parseRegex(rule.Pattern, false)
+			if err != nil {
+				return nil, err
+			}
+			node.collectAlphabet(alphabet)
+			node.collectBoundaries(boundaries)
+			patterns[i] = node
+		case rule.Automaton != nil:
+			n, err := rule.Automaton.ToNFA()
+			if err != nil {
+				return nil, err
+			}
+			for input := range n.inputs {
+				alphabet[input] = true
+			}
+			automata[i] = n
+		default:
+			return nil, errors.New(fmt.Sprintln("Invalid lexer rule - no pattern or automaton for: ", rule.Name))
+		}
+	}
+
+	// Rules are added in order, so the states of a rule always have lower ids
+	// than the states of the rules after it, and the subset construction picks
+	// the output of the first rule accepting.
+	for input := range alphabet {
+		if utf8.ValidString(input) && utf8.RuneCountInString(input) == 1 {
+			char, _ := utf8.DecodeRuneInString(input)
+			boundaries[char], boundaries[char+1] = true, true
+		}
+	}
+
+	// Surrogates never come out of decoding, so their interval gets no symbol.
+	sorted := []rune{}
+	runes := []rune{}
+	for char := range boundaries {
+		if char <= unicode.MaxRune {
+			sorted = append(sorted, char)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	for _, char := range sorted {
+		if char != 0xd800 {
+			runes = append(runes, char)
+			alphabet[string(char)] = true
+		}
+	}
+
+	n := NewNFA(alphabet)
+	start := n.AddState("", false)
+	for i, rule := range rules {
+		var ruleStart int
+		if patterns[i] != nil {
+			var ruleEnd int
+			ruleStart, ruleEnd = patterns[i].addTo(n, runes)
+			n.accepting[ruleEnd] = true
+			n.outputs[ruleEnd] = rule.Name
+		} else {
+			ruleStart = n.embed(automata[i], rule.Name)
+		}
+		n.AddEpsilonTransition(start, ruleStart)
+	}
+
+	automaton, err := n.Determinize()
+	if err != nil {
+		return nil, err
+	}
+
+	if automaton.acceptingStates[automaton.initialState] {
+		return nil, errors.New(fmt.Sprintln("Invalid lexer rule - accepts the empty string: ", automaton.initialState.output))
+	}
+
+	l := &Lexer{automaton: automaton, skip: map[string]bool{}, boundaries: sorted}
+	for _, rule := range rules {
+		if rule.Skip {
+			l.skip[rule.Name] = true
+		}
+	}
+
+	return l, nil
+}
+
+// Function to split the input into tokens
+//   - at every offset the longest text accepted by any rule is taken
+//   - returns an error with the offset, line and column where no rule matches
+func (l *Lexer) Tokenize(input string) ([]Token, error) {
+	tokens := []Token{}
+	for offset := 0; offset < len(input); {
+		end, kind := l.longestMatch(input, offset)
+		if end < 0 {
+			line := strings.Count(input[:offset], "\n") + 1
+			column := utf8.RuneCountInString(input[strings.LastIndex(input[:offset], "\n")+1:offset]) + 1
+			return nil, errors.New(fmt.Sprintln("Invalid token at offset ", offset, " (line ", line, ", column ", column, ")"))
+		}
+
+		if !l.skip[kind] {
+			tokens = append(tokens, Token{Kind: kind, Text: input[offset:end], Offset: offset})
+		}
+		offset = end
+	}
+
+	return tokens, nil
+}
+
+// GetAutomation returns the DFA of all rules, an input symbol standing for the
+// runes from it up to the next boundary
+func (l *Lexer) GetAutomation() *FiniteAutomation {
+	return l.automaton
+}
+
+// longestMatch returns the end offset and kind of the longest token at the
+// offset, or -1 when no rule matches
+func (l *Lexer) longestMatch(input string, offset int) (int, string) {
+	end, kind := -1, ""
+
+	ref := l.automaton.initialState
+	for i := offset; i < len(input); {
+		char, size := utf8.DecodeRuneInString(input[i:])
+		i += size
+
+		next, ok := ref.transition[l.symbol(char)]
+		if !ok {
+			break
+		}
+
+		ref = next
+		if l.automaton.acceptingStates[ref] {
+			end, kind = i, ref.output
+		}
+	}
+
+	return end, kind
+}
+
+// symbol returns the input symbol of the interval containing the rune
+func (l *Lexer) symbol(char rune) string {
+	i := sort.Search(len(l.boundaries), func(i int) bool { return l.boundaries[i] > char })
+
+	return string(l.boundaries[i-1])
+}
+
+// embed copies the states and transitions of another NFA into this one - returns
+// the id of the copy of its initial state
+//   - the copies of accepting states output the given output, the others output nothing
+func (n *NFA) embed(other *NFA, output string) int {
+	offset := n.include(other)
+	for state := range other.outputs {
+		n.outputs[offset+state] = ""
+		if other.accepting[state] {
+			n.outputs[offset+state] = output
+		}
+	}
+
+	return offset + other.initialState
+}
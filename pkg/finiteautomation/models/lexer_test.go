@@ -0,0 +1,182 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// GetMockLexer returns a lexer for a small expression language.
+func GetMockLexer() *Lexer {
+	l, _ := NewLexer([]LexerRule{
+		{Name: "IF", Pattern: "if"},
+		{Name: "IDENT", Pattern: "[a-zA-Z_][a-zA-Z0-9_]*"},
+		{Name: "NUMBER", Pattern: "[0-9]+"},
+		{Name: "OP", Pattern: "==|=|\\+"},
+		{Name: "WS", Pattern: "[ \\t\\n]+", Skip: true},
+	})
+
+	return l
+}
+
+// TestLexer_Tokenize tests maximal munch and priority between rules.
+func TestLexer_Tokenize(t *testing.T) {
+	l := GetMockLexer()
+
+	tokens, err := l.Tokenize("if iffy == 42\nx=x+1")
+
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	expected := []Token{
+		{Kind: "IF", Text: "if", Offset: 0},
+		{Kind: "IDENT", Text: "iffy", Offset: 3},
+		{Kind: "OP", Text: "==", Offset: 8},
+		{Kind: "NUMBER", Text: "42", Offset: 11},
+		{Kind: "IDENT", Text: "x", Offset: 14},
+		{Kind: "OP", Text: "=", Offset: 15},
+		{Kind: "IDENT", Text: "x", Offset: 16},
+		{Kind: "OP", Text: "+", Offset: 17},
+		{Kind: "NUMBER", Text: "1", Offset: 18},
+	}
+	if !reflect.DeepEqual(tokens, expected) {
+		t.Errorf("Expected %v, got %v", expected, tokens)
+	}
+}
+
+// TestLexer_LiteralOperators tests that '&' and '~' are plain runes in rules.
+func TestLexer_LiteralOperators(t *testing.T) {
+	l, err := NewLexer([]LexerRule{
+		{Name: "AND", Pattern: "&&"},
+		{Name: "NOT", Pattern: "~"},
+		{Name: "IDENT", Pattern: "[a-z]+"},
+	})
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	tokens, err := l.Tokenize("a&&~b")
+
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	expected := []Token{
+		{Kind: "IDENT", Text: "a", Offset: 0},
+		{Kind: "AND", Text: "&&", Offset: 1},
+		{Kind: "NOT", Text: "~", Offset: 3},
+		{Kind: "IDENT", Text: "b", Offset: 4},
+	}
+	if !reflect.DeepEqual(tokens, expected) {
+		t.Errorf("Expected %v, got %v", expected, tokens)
+	}
+}
+
+// TestLexer_OtherRunes tests that '.' and negated classes match runes appearing
+// in no rule.
+func TestLexer_OtherRunes(t *testing.T) {
+	l, err := NewLexer([]LexerRule{
+		{Name: "STRING", Pattern: "\"[^\"]*\""},
+		{Name: "COMMENT", Pattern: "#.*"},
+		{Name: "CJK", Pattern: "[一-龥]+"},
+		{Name: "WS", Pattern: "[ \n]+", Skip: true},
+	})
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	tokens, err := l.Tokenize("\"é\" 中文 #ü😀\n")
+
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	expected := []Token{
+		{Kind: "STRING", Text: "\"é\"", Offset: 0},
+		{Kind: "CJK", Text: "中文", Offset: 5},
+		{Kind: "COMMENT", Text: "#ü😀", Offset: 12},
+	}
+	if !reflect.DeepEqual(tokens, expected) {
+		t.Errorf("Expected %v, got %v", expected, tokens)
+	}
+
+	if _, err := l.Tokenize("é"); err == nil {
+		t.Errorf("Expected error for a rune outside every rule, got nil")
+	}
+}
+
+// TestLexer_InvalidUTF8 tests that a token over an invalid byte ends after the
+// byte read.
+func TestLexer_InvalidUTF8(t *testing.T) {
+	start, replaced := newState("start"), newState("replaced")
+	tf := TransitionFunction{}
+	tf.Initialize(start, "\uFFFD", replaced)
+	invalid, _ := newFiniteAutomation([]*State{start, replaced}, map[string]bool{"\uFFFD": true}, start, []*State{replaced}, []TransitionFunction{tf})
+
+	l, _ := NewLexer([]LexerRule{
+		{Name: "IDENT", Pattern: "[a-z]+"},
+		{Name: "INVALID", Automaton: invalid},
+	})
+
+	tokens, err := l.Tokenize("a\xffb")
+
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	expected := []Token{
+		{Kind: "IDENT", Text: "a", Offset: 0},
+		{Kind: "INVALID", Text: "\xff", Offset: 1},
+		{Kind: "IDENT", Text: "b", Offset: 2},
+	}
+	if !reflect.DeepEqual(tokens, expected) {
+		t.Errorf("Expected %v, got %v", expected, tokens)
+	}
+}
+
+// TestLexer_ErrorPosition tests that the error reports where no rule matches.
+func TestLexer_ErrorPosition(t *testing.T) {
+	l := GetMockLexer()
+
+	_, err := l.Tokenize("x = 1\ny = $")
+
+	if err == nil {
+		t.Fatalf("Expected error for invalid token, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "offset  10") || !strings.Contains(err.Error(), "line  2") || !strings.Contains(err.Error(), "column  5") {
+		t.Errorf("Expected error at offset 10, line 2, column 5, got %s", err.Error())
+	}
+}
+
+// TestLexer_AutomatonRule tests that an automaton can be used as a rule.
+func TestLexer_AutomatonRule(t *testing.T) {
+	ones := GetMockOnesFiniteAutomation()
+	l, err := NewLexer([]LexerRule{
+		{Name: "ONES", Automaton: &ones},
+		{Name: "ZERO", Pattern: "0"},
+	})
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	tokens, err := l.Tokenize("110111")
+
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	if len(tokens) != 3 || tokens[0].Kind != "ONES" || tokens[2].Text != "111" {
+		t.Errorf("Expected ONES ZERO ONES, got %v", tokens)
+	}
+}
+
+// TestLexer_ErrorEmptyMatch tests that rules accepting the empty string are rejected.
+func TestLexer_ErrorEmptyMatch(t *testing.T) {
+	_, err := NewLexer([]LexerRule{{Name: "A", Pattern: "a*"}})
+
+	if err == nil {
+		t.Errorf("Expected error for rule accepting the empty string, got nil")
+	}
+}
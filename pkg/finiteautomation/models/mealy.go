@@ -0,0 +1,124 @@
+package models
+
+import (
+	"errors"
+	"strings"
+)
+
+// Function to translate an input with a Mealy machine - returns the concatenated
+// outputs of the transitions taken
+//   - transitions without output contribute nothing
+//   - same errors as Compute
+func (fa *FiniteAutomation) Translate(input string) (*string, error) {
+	return fa.TranslateWithContext(input, nil)
+}
+
+// Function to translate an input with runtime data for guarded transitions
+//   - guarded transitions are evaluated like in ComputeWithContext
+//   - same errors as Compute
+func (fa *FiniteAutomation) TranslateWithContext(input string, ctx Context) (*string, error) {
+	var builder strings.Builder
+
+	_, err := fa.run(runeSymbols(input), ctx, func(transitionOutput string, state *State) {
+		builder.WriteString(transitionOutput)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := builder.String()
+
+	return &result, nil
+}
+
+// Function to convert a Moore machine into an equivalent Mealy machine
+//   - every transition outputs the output of the state it leads to
+//   - states keep their outputs, so the result is still usable as a Moore machine
+func (fa *FiniteAutomation) ToMealy() (*FiniteAutomation, error) {
+	if fa == nil || fa.states == nil || fa.initialState == nil {
+		return nil, errors.New("finite Automation has not been initialized")
+	}
+
+	copies := map[*State]*State{}
+	states := []*State{}
+	for _, state := range fa.orderedStates() {
+		copies[state] = newState(state.output)
+		states = append(states, copies[state])
+	}
+
+	acceptingStates := []*State{}
+	for _, state := range fa.orderedStates() {
+		if fa.acceptingStates[state] {
+			acceptingStates = append(acceptingStates, copies[state])
+		}
+	}
+
+	transitionFunctions := []TransitionFunction{}
+	for _, transitionFunction := range fa.transitionFunctions {
+		tf := TransitionFunction{}
+		tf.Initialize(copies[transitionFunction.currentState], transitionFunction.input, copies[transitionFunction.transitionState])
+		tf.SetOutput(transitionFunction.transitionState.output)
+		tf.SetGuard(transitionFunction.guard)
+		transitionFunctions = append(transitionFunctions, tf)
+	}
+
+	return newFiniteAutomation(states, fa.inputs, copies[fa.initialState], acceptingStates, transitionFunctions)
+}
+
+// Function to convert a Mealy machine into an equivalent Moore machine
+//   - every state is split by the outputs of the transitions entering it, the
+//     output of a transition becomes the output of the state it leads to
+//   - the initial state gets an empty output
+//   - the Moore output of a word is the sequence of outputs of the states entered,
+//     which matches the Mealy output sequence of the original machine
+func (fa *FiniteAutomation) ToMoore() (*FiniteAutomation, error) {
+	if fa == nil || fa.states == nil || fa.initialState == nil {
+		return nil, errors.New("finite Automation has not been initialized")
+	}
+
+	type mooreKey struct {
+		state  *State
+		output string
+	}
+
+	splits := map[mooreKey]*State{}
+	keys := []mooreKey{}
+	states := []*State{}
+	acceptingStates := []*State{}
+	split := func(state *State, output string) *State {
+		key := mooreKey{state, output}
+		if mooreState, ok := splits[key]; ok {
+			return mooreState
+		}
+
+		mooreState := newState(output)
+		splits[key] = mooreState
+		keys = append(keys, key)
+		states = append(states, mooreState)
+		if fa.acceptingStates[state] {
+			acceptingStates = append(acceptingStates, mooreState)
+		}
+
+		return mooreState
+	}
+
+	initialState := split(fa.initialState, "")
+
+	// Every split of a state needs a copy of the transitions leaving that state,
+	// so keep splitting until no new (state, output) pair appears.
+	transitionFunctions := []TransitionFunction{}
+	for i := 0; i < len(states); i++ {
+		for _, transitionFunction := range fa.transitionFunctions {
+			if transitionFunction.currentState != keys[i].state {
+				continue
+			}
+
+			tf := TransitionFunction{}
+			tf.Initialize(states[i], transitionFunction.input, split(transitionFunction.transitionState, transitionFunction.output))
+			tf.SetGuard(transitionFunction.guard)
+			transitionFunctions = append(transitionFunctions, tf)
+		}
+	}
+
+	return newFiniteAutomation(states, fa.inputs, initialState, acceptingStates, transitionFunctions)
+}
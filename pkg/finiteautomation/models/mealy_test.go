@@ -0,0 +1,158 @@
+package models
+
+import "testing"
+
+// GetMockMealyFiniteAutomation returns a Mealy machine over {0, 1} that
+// outputs "e" on the first symbol and then "c" when a symbol repeats the
+// previous one and "t" when it toggles.
+func GetMockMealyFiniteAutomation() FiniteAutomation {
+	start, last0, last1 := State{}, State{}, State{}
+	start.Initialize("start", map[string]*State{})
+	last0.Initialize("last0", map[string]*State{})
+	last1.Initialize("last1", map[string]*State{})
+
+	transitionFunctions := []TransitionFunction{}
+	for _, transition := range []struct {
+		from   *State
+		input  string
+		to     *State
+		output string
+	}{
+		{&start, "0", &last0, "e"},
+		{&start, "1", &last1, "e"},
+		{&last0, "0", &last0, "c"},
+		{&last0, "1", &last1, "t"},
+		{&last1, "0", &last0, "t"},
+		{&last1, "1", &last1, "c"},
+	} {
+		tf := TransitionFunction{}
+		tf.Initialize(transition.from, transition.input, transition.to)
+		tf.SetOutput(transition.output)
+		transitionFunctions = append(transitionFunctions, tf)
+	}
+
+	finiteStates := map[*State]*State{&start: &start, &last0: &last0, &last1: &last1}
+	acceptingStates := []*State{&start, &last0, &last1}
+	inputs := map[string]bool{"0": true, "1": true}
+
+	fa := FiniteAutomation{}
+	fa.InitializeFiniteAutomation(finiteStates, inputs, &start, acceptingStates, transitionFunctions)
+
+	return fa
+}
+
+// TestTranslate_NoError tests that Translate concatenates the outputs of the
+// transitions taken.
+func TestTranslate_NoError(t *testing.T) {
+	fa := GetMockMealyFiniteAutomation()
+
+	result, err := fa.Translate("00110")
+
+	if err != nil {
+		t.Errorf("Expected nil error, got %v", err)
+	}
+
+	if *result != "ectct" {
+		t.Errorf("Expected %s, got %s", "ectct", *result)
+	}
+}
+
+// TestTranslate_ErrorInvalidInput tests that Translate returns an error for
+// symbols not defined in the automaton's input set.
+func TestTranslate_ErrorInvalidInput(t *testing.T) {
+	fa := GetMockMealyFiniteAutomation()
+
+	result, err := fa.Translate("012")
+
+	if err == nil {
+		t.Errorf("Expected error for invalid input, got nil")
+	}
+
+	if result != nil {
+		t.Errorf("Expected nil result, got %s", *result)
+	}
+}
+
+// TestToMealy_NoError tests that converting a Moore machine outputs the
+// output of every state entered.
+func TestToMealy_NoError(t *testing.T) {
+	fa := GetMockFiniteAutomation()
+
+	mealy, err := fa.ToMealy()
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	result, err := mealy.Translate("0101")
+
+	if err != nil {
+		t.Errorf("Expected nil error, got %v", err)
+	}
+
+	if *result != "1010" {
+		t.Errorf("Expected %s, got %s", "1010", *result)
+	}
+}
+
+// TestToMoore_RoundTrip tests that converting a Mealy machine to Moore form and
+// back keeps the translation of every input.
+func TestToMoore_RoundTrip(t *testing.T) {
+	fa := GetMockMealyFiniteAutomation()
+
+	moore, err := fa.ToMoore()
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	mealy, err := moore.ToMealy()
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	for _, input := range []string{"", "0", "01", "00110", "111010"} {
+		expected, _ := fa.Translate(input)
+		result, err := mealy.Translate(input)
+
+		if err != nil {
+			t.Errorf("Expected nil error, got %v", err)
+			continue
+		}
+
+		if *result != *expected {
+			t.Errorf("Expected %s, got %s for input %s", *expected, *result, input)
+		}
+	}
+}
+
+// TestTranslateWithContext_GuardTaken tests that the output of the guarded
+// transition taken for the context is emitted.
+func TestTranslateWithContext_GuardTaken(t *testing.T) {
+	pending, paid := State{}, State{}
+	pending.Initialize("Pending", map[string]*State{})
+	paid.Initialize("Paid", map[string]*State{})
+
+	tf1 := TransitionFunction{}
+	tf1.Initialize(&pending, "P", &paid)
+	tf1.SetOutput("full")
+	tf1.SetGuard(func(ctx Context) bool {
+		return ctx["amount"].(int) >= ctx["total"].(int)
+	})
+	tf2 := TransitionFunction{}
+	tf2.Initialize(&pending, "P", &pending)
+	tf2.SetOutput("partial")
+	transitionFunctions := []TransitionFunction{tf1, tf2}
+
+	finiteStates := map[*State]*State{&pending: &pending, &paid: &paid}
+	fa := FiniteAutomation{}
+	fa.InitializeFiniteAutomation(finiteStates, map[string]bool{"P": true}, &pending, []*State{&paid}, transitionFunctions)
+
+	result, err := fa.TranslateWithContext("P", Context{"amount": 100, "total": 100})
+
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	if *result != "full" {
+		t.Errorf("Expected %s, got %s", "full", *result)
+	}
+}
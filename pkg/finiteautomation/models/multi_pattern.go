@@ -0,0 +1,164 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MultiPatternMatcher combines several automata into a single product DFA.
+// It contains:
+//   - automaton: the product automaton over the union of the input symbols, its
+//     accepting states are the states where at least one pattern accepts.
+//   - patterns: for every state of the product, the ids (indexes in the list of
+//     patterns) of the patterns accepting there, in increasing order.
+type MultiPatternMatcher struct {
+	automaton *FiniteAutomation
+	patterns  map[*State][]int
+}
+
+// Function to combine the patterns into a MultiPatternMatcher
+//   - every product state tracks the state of every pattern, a pattern drops out
+//     once it has no transition or can no longer reach an accepting state
+//   - a product state outputs the comma separated ids of the patterns accepting there
+//   - guarded transitions are not supported
+func NewMultiPatternMatcher(patterns []*FiniteAutomation) (*MultiPatternMatcher, error) {
+	if len(patterns) == 0 {
+		return nil, errors.New(fmt.Sprintln("Invalid nil pointer"))
+	}
+
+	inputs := map[string]bool{}
+	ids := make([]map[*State]int, len(patterns))
+	live := make([]map[*State]bool, len(patterns))
+	for i, pattern := range patterns {
+		if err := pattern.checkUnguarded(); err != nil {
+			return nil, err
+		}
+
+		for input := range pattern.inputs {
+			inputs[input] = true
+		}
+
+		ids[i] = map[*State]int{}
+		for id, state := range pattern.orderedStates() {
+			ids[i][state] = id
+		}
+		live[i] = pattern.liveStates()
+	}
+
+	m := &MultiPatternMatcher{patterns: map[*State][]int{}}
+	product := map[string]*State{}
+	tuples := [][]*State{}
+	states := []*State{}
+	acceptingStates := []*State{}
+	visit := func(tuple []*State) *State {
+		var key strings.Builder
+		for i, state := range tuple {
+			if state == nil {
+				key.WriteString("-,")
+				continue
+			}
+			key.WriteString(strconv.Itoa(ids[i][state]))
+			key.WriteByte(',')
+		}
+
+		if state, ok := product[key.String()]; ok {
+			return state
+		}
+
+		accepted := []int{}
+		labels := []string{}
+		for i, state := range tuple {
+			if state != nil && patterns[i].acceptingStates[state] {
+				accepted = append(accepted, i)
+				labels = append(labels, strconv.Itoa(i))
+			}
+		}
+
+		state := newState(strings.Join(labels, ","))
+		product[key.String()] = state
+		tuples = append(tuples, tuple)
+		states = append(states, state)
+		if len(accepted) > 0 {
+			m.patterns[state] = accepted
+			acceptingStates = append(acceptingStates, state)
+		}
+
+		return state
+	}
+
+	initial := make([]*State, len(patterns))
+	for i, pattern := range patterns {
+		if live[i][pattern.initialState] {
+			initial[i] = pattern.initialState
+		}
+	}
+	initialState := visit(initial)
+
+	sortedInputs := make([]string, 0, len(inputs))
+	for input := range inputs {
+		sortedInputs = append(sortedInputs, input)
+	}
+	sort.Strings(sortedInputs)
+
+	transitionFunctions := []TransitionFunction{}
+	for i := 0; i < len(tuples); i++ {
+		for _, input := range sortedInputs {
+			next := make([]*State, len(patterns))
+			alive := false
+			for j, state := range tuples[i] {
+				if state == nil {
+					continue
+				}
+
+				if target, ok := state.transition[input]; ok && live[j][target] {
+					next[j] = target
+					alive = true
+				}
+			}
+
+			if !alive {
+				continue
+			}
+
+			tf := TransitionFunction{}
+			tf.Initialize(states[i], input, visit(next))
+			transitionFunctions = append(transitionFunctions, tf)
+		}
+	}
+
+	automaton, err := newFiniteAutomation(states, inputs, initialState, acceptingStates, transitionFunctions)
+	if err != nil {
+		return nil, err
+	}
+	m.automaton = automaton
+
+	return m, nil
+}
+
+// Function to find which patterns accept the input in a single pass
+//   - returns the ids of the accepting patterns in increasing order, nil if none
+func (m *MultiPatternMatcher) Match(input string) []int {
+	ref := m.automaton.initialState
+	for _, char := range input {
+		next, ok := ref.transition[string(char)]
+		if !ok {
+			return nil
+		}
+		ref = next
+	}
+
+	return m.patterns[ref]
+}
+
+// GetAutomation returns the product automaton, e.g. to search it with FindAll
+func (m *MultiPatternMatcher) GetAutomation() *FiniteAutomation {
+	return m.automaton
+}
+
+// GetPatterns returns the ids of the patterns accepting in a state of the product automaton
+func (m *MultiPatternMatcher) GetPatterns(state *State) []int {
+	return m.patterns[state]
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestMultiPatternMatcher_Match tests that a single pass reports every pattern
+// accepting the input.
+func TestMultiPatternMatcher_Match(t *testing.T) {
+	even := GetMockFiniteAutomation()                   // "01" repeated
+	moduloThree := GetMockModuloThreeFiniteAutomation() // every binary string
+	ones := GetMockOnesFiniteAutomation()               // one or more 1s
+
+	m, err := NewMultiPatternMatcher([]*FiniteAutomation{&even, &moduloThree, &ones})
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	for input, expected := range map[string][]int{
+		"":     {0, 1},
+		"0101": {0, 1},
+		"11":   {1, 2},
+		"10":   {1},
+		"x":    nil,
+		"2":    nil,
+	} {
+		result := m.Match(input)
+
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Expected %v, got %v for input %s", expected, result, input)
+		}
+	}
+}
+
+// TestMultiPatternMatcher_FindAll tests that the product automaton can be
+// searched and its matches mapped back to pattern ids.
+func TestMultiPatternMatcher_FindAll(t *testing.T) {
+	ones := GetMockOnesFiniteAutomation()
+
+	m, err := NewMultiPatternMatcher([]*FiniteAutomation{&ones})
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	matches := m.GetAutomation().FindAll("0110x1", MatchLeftmostLongest)
+
+	if len(matches) != 2 || !reflect.DeepEqual(m.GetPatterns(matches[0].State), []int{0}) {
+		t.Errorf("Expected 2 matches of pattern 0, got %v", matches)
+	}
+}
+
+// TestMultiPatternMatcher_ErrorNoPatterns tests that an empty list of patterns is rejected.
+func TestMultiPatternMatcher_ErrorNoPatterns(t *testing.T) {
+	_, err := NewMultiPatternMatcher(nil)
+
+	if err == nil {
+		t.Errorf("Expected error for empty patterns, got nil")
+	}
+}
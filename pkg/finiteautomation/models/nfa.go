@@ -0,0 +1,317 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// NFA defines a nondeterministic finite automaton model.
+// It includes:
+//   - inputs: the valid input symbols the automaton can process.
+//   - outputs: the output of every state, indexed by state id.
+//   - accepting: for every state id, whether the state is an accepting state.
+//   - transitions: for every state id, a mapping of input symbols to the next state ids.
+//   - epsilon: for every state id, the states reachable without reading input.
+//   - initialState: the id of the starting state.
+type NFA struct {
+	inputs       map[string]bool
+	outputs      []string
+	accepting    []bool
+	transitions  []map[string][]int
+	epsilon      [][]int
+	initialState int
+}
+
+// Function to create an NFA over the given input symbols
+//   - the automaton has no states yet, the first state added becomes the initial state
+func NewNFA(inputs map[string]bool) *NFA {
+	n := &NFA{inputs: map[string]bool{}}
+	for input := range inputs {
+		n.inputs[input] = true
+	}
+
+	return n
+}
+
+// Function to add a state - returns the id of the new state
+func (n *NFA) AddState(output string, accepting bool) int {
+	n.outputs = append(n.outputs, output)
+	n.accepting = append(n.accepting, accepting)
+	n.transitions = append(n.transitions, map[string][]int{})
+	n.epsilon = append(n.epsilon, nil)
+
+	return len(n.outputs) - 1
+}
+
+// Function to add a transition from one state to another on an input
+//   - both states must exist and the input must be in the set of inputs
+//   - several transitions may share a starting state and input
+func (n *NFA) AddTransition(from int, input string, to int) error {
+	if !n.isState(from) || !n.isState(to) {
+		return errors.New(fmt.Sprintln("Transition Function invalid - State not in the set of states: ", from, to))
+	}
+
+	if !n.inputs[input] {
+		return errors.New(fmt.Sprintln("Transition Function invalid - Input not in the set of finite inputs", input))
+	}
+
+	for _, target := range n.transitions[from][input] {
+		if target == to {
+			return nil
+		}
+	}
+
+	n.transitions[from][input] = append(n.transitions[from][input], to)
+
+	return nil
+}
+
+// Function to add a transition that does not read any input
+func (n *NFA) AddEpsilonTransition(from int, to int) error {
+	if !n.isState(from) || !n.isState(to) {
+		return errors.New(fmt.Sprintln("Transition Function invalid - State not in the set of states: ", from, to))
+	}
+
+	for _, target := range n.epsilon[from] {
+		if target == to {
+			return nil
+		}
+	}
+
+	n.epsilon[from] = append(n.epsilon[from], to)
+
+	return nil
+}
+
+// Function to set the initial state
+func (n *NFA) SetInitialState(state int) error {
+	if !n.isState(state) {
+		return errors.New(fmt.Sprintln("Initial State invalid - Initial state not in the set of states: ", state))
+	}
+
+	n.initialState = state
+
+	return nil
+}
+
+func (n *NFA) NumStates() int {
+	return len(n.outputs)
+}
+
+func (n *NFA) GetInitialState() int {
+	return n.initialState
+}
+
+func (n *NFA) GetOutput(state int) string {
+	return n.outputs[state]
+}
+
+func (n *NFA) IsAccepting(state int) bool {
+	return n.accepting[state]
+}
+
+// Function to check if the input is accepted by simulating the set of active states
+//   - every rune of the input is one input symbol, like in Compute
+//   - returns false for undefined inputs
+func (n *NFA) Accepts(input string) bool {
+	if n == nil || len(n.outputs) == 0 {
+		return false
+	}
+
+	current := n.closure([]int{n.initialState})
+	for _, char := range input {
+		s := string(char)
+		if !n.inputs[s] {
+			return false
+		}
+
+		current = n.step(current, s)
+		if len(current) == 0 {
+			return false
+		}
+	}
+
+	for _, state := range current {
+		if n.accepting[state] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Function to convert the NFA into an equivalent FiniteAutomation (subset construction)
+//   - every reachable non-empty set of NFA states becomes one state
+//   - a set is accepting if any of its states is accepting, its output is the
+//     output of its lowest accepting state id, or of its lowest state id if none
+//     is accepting
+//   - transitions to the empty set are left undefined
+func (n *NFA) Determinize() (*FiniteAutomation, error) {
+	if n == nil || len(n.outputs) == 0 {
+		return nil, errors.New("nfa has not been initialized")
+	}
+
+	inputs := make([]string, 0, len(n.inputs))
+	for input := range n.inputs {
+		inputs = append(inputs, input)
+	}
+	sort.Strings(inputs)
+
+	subsets := map[string]*State{}
+	pending := [][]int{}
+	states := []*State{}
+	acceptingStates := []*State{}
+	visit := func(set []int) *State {
+		key := setKey(set)
+		if state, ok := subsets[key]; ok {
+			return state
+		}
+
+		output, accepting := n.outputs[set[0]], false
+		for _, member := range set {
+			if n.accepting[member] {
+				output, accepting = n.outputs[member], true
+				break
+			}
+		}
+
+		state := newState(output)
+		subsets[key] = state
+		pending = append(pending, set)
+		states = append(states, state)
+		if accepting {
+			acceptingStates = append(acceptingStates, state)
+		}
+
+		return state
+	}
+
+	initialState := visit(n.closure([]int{n.initialState}))
+	transitionFunctions := []TransitionFunction{}
+	for i := 0; i < len(pending); i++ {
+		for _, input := range inputs {
+			next := n.step(pending[i], input)
+			if len(next) == 0 {
+				continue
+			}
+
+			tf := TransitionFunction{}
+			tf.Initialize(states[i], input, visit(next))
+			transitionFunctions = append(transitionFunctions, tf)
+		}
+	}
+
+	return newFiniteAutomation(states, n.inputs, initialState, acceptingStates, transitionFunctions)
+}
+
+// Function to convert the FiniteAutomation into an NFA
+//   - state ids follow the breadth-first order from the initial state, which gets id 0
+//   - guarded transitions are not supported
+func (fa *FiniteAutomation) ToNFA() (*NFA, error) {
+	if err := fa.checkUnguarded(); err != nil {
+		return nil, err
+	}
+
+	n := NewNFA(fa.inputs)
+	ids := map[*State]int{}
+	states := fa.orderedStates()
+	for _, state := range states {
+		ids[state] = n.AddState(state.output, fa.acceptingStates[state])
+	}
+
+	for _, state := range states {
+		for input, next := range state.transition {
+			if err := n.AddTransition(ids[state], input, ids[next]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return n, nil
+}
+
+// include copies the states and transitions of another NFA into this one - returns
+// the offset added to the ids of the copied states
+//   - the input symbols of the other NFA are added to this one
+func (n *NFA) include(other *NFA) int {
+	for input := range other.inputs {
+		n.inputs[input] = true
+	}
+
+	offset := len(n.outputs)
+	for state := range other.outputs {
+		n.AddState(other.outputs[state], other.accepting[state])
+	}
+
+	for state := range other.outputs {
+		for input, targets := range other.transitions[state] {
+			for _, target := range targets {
+				n.AddTransition(offset+state, input, offset+target)
+			}
+		}
+
+		for _, target := range other.epsilon[state] {
+			n.AddEpsilonTransition(offset+state, offset+target)
+		}
+	}
+
+	return offset
+}
+
+// step returns the epsilon closure of the states reached from the set on the input
+func (n *NFA) step(set []int, input string) []int {
+	next := []int{}
+	for _, state := range set {
+		next = append(next, n.transitions[state][input]...)
+	}
+
+	return n.closure(next)
+}
+
+// closure returns the sorted set of states reachable from the given states
+// through epsilon transitions, the given states included
+func (n *NFA) closure(set []int) []int {
+	seen := map[int]bool{}
+	stack := []int{}
+	for _, state := range set {
+		if !seen[state] {
+			seen[state] = true
+			stack = append(stack, state)
+		}
+	}
+
+	result := []int{}
+	for len(stack) > 0 {
+		state := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		result = append(result, state)
+		for _, next := range n.epsilon[state] {
+			if !seen[next] {
+				seen[next] = true
+				stack = append(stack, next)
+			}
+		}
+	}
+
+	sort.Ints(result)
+
+	return result
+}
+
+func (n *NFA) isState(state int) bool {
+	return state >= 0 && state < len(n.outputs)
+}
+
+// setKey returns a map key for a sorted set of state ids
+func setKey(set []int) string {
+	var key strings.Builder
+	for _, state := range set {
+		key.WriteString(strconv.Itoa(state))
+		key.WriteByte(',')
+	}
+
+	return key.String()
+}
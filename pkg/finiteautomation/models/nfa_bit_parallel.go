@@ -0,0 +1,219 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// maxBitParallelStates is the number of states that fit in the uint64 state mask
+const maxBitParallelStates = 64
+
+// BitParallelNFA simulates an NFA of at most 64 states with its set of active
+// states kept as a uint64 bitmask.
+// It contains:
+//   - symbols: dense ids of the single-rune input symbols.
+//   - bits: the bit of every state in the mask, -1 for an implicit initial state.
+//   - shifts: the distinct distances between the bits of the NFA transitions.
+//   - masks: masks[symbol][k] holds the states with a transition on the symbol
+//     over the distance shifts[k], so one step is a few ANDs and shifts.
+//   - closure: the epsilon closure of every byte of the state mask, so closing
+//     a mask takes eight lookups.
+//   - initial: the epsilon closure of the initial state.
+//   - implicitInitial: whether the initial state has no bit, its transitions are
+//     then kept in first and its acceptance in initialAccepting.
+//   - accepting: the mask of the accepting states.
+//
+// For a Glushkov (Shift-And) automaton, as built by NewGlushkovNFA, the
+// initial state is implicit so the 64 bits hold 64 positions, and a literal run
+// of the pattern goes from position i to i+1, so its step is a single shift and mask.
+type BitParallelNFA struct {
+	symbols          map[rune]int
+	shifts           []int
+	masks            [][]uint64
+	hasEpsilon       bool
+	closure          [8][256]uint64
+	initial          uint64
+	implicitInitial  bool
+	first            []uint64
+	initialAccepting bool
+	accepting        uint64
+}
+
+// Function to build the bit-parallel simulator of the NFA
+//   - when the NFA has no epsilon transitions and no transition into the initial
+//     state, like a Glushkov NFA, the initial state does not take a bit of the mask
+//   - returns an error if the other states do not fit in 64 bits
+//   - only single-rune input symbols can be matched, like in Accepts
+func (n *NFA) BitParallel() (*BitParallelNFA, error) {
+	if n == nil || len(n.outputs) == 0 {
+		return nil, errors.New("nfa has not been initialized")
+	}
+
+	b := &BitParallelNFA{symbols: map[rune]int{}}
+	incoming := false
+	for from, transitions := range n.transitions {
+		if len(n.epsilon[from]) > 0 {
+			b.hasEpsilon = true
+		}
+		for _, targets := range transitions {
+			for _, to := range targets {
+				incoming = incoming || to == n.initialState
+			}
+		}
+	}
+	b.implicitInitial = !b.hasEpsilon && !incoming
+
+	bits := make([]int, len(n.outputs))
+	for state := range bits {
+		switch {
+		case !b.implicitInitial || state < n.initialState:
+			bits[state] = state
+		case state == n.initialState:
+			bits[state] = -1
+		default:
+			bits[state] = state - 1
+		}
+	}
+
+	if states := len(n.outputs); states > maxBitParallelStates && !(b.implicitInitial && states-1 <= maxBitParallelStates) {
+		return nil, errors.New(fmt.Sprintln("Too many states for bit-parallel simulation: ", states))
+	}
+
+	for input := range n.inputs {
+		char, size := utf8.DecodeRuneInString(input)
+		if size == len(input) && char != utf8.RuneError {
+			b.symbols[char] = len(b.symbols)
+		}
+	}
+
+	shiftIndex := map[int]int{}
+	b.masks = make([][]uint64, len(b.symbols))
+	b.first = make([]uint64, len(b.symbols))
+	for from, transitions := range n.transitions {
+		for input, targets := range transitions {
+			char, size := utf8.DecodeRuneInString(input)
+			symbol, ok := b.symbols[char]
+			if !ok || size != len(input) {
+				continue
+			}
+
+			for _, to := range targets {
+				if bits[from] == -1 {
+					b.first[symbol] |= 1 << uint(bits[to])
+					continue
+				}
+
+				shift := bits[to] - bits[from]
+				k, ok := shiftIndex[shift]
+				if !ok {
+					k = len(b.shifts)
+					shiftIndex[shift] = k
+					b.shifts = append(b.shifts, shift)
+					for s := range b.masks {
+						b.masks[s] = append(b.masks[s], 0)
+					}
+				}
+
+				b.masks[symbol][k] |= 1 << uint(bits[from])
+			}
+		}
+	}
+
+	if b.implicitInitial {
+		b.initialAccepting = n.accepting[n.initialState]
+		for state, accepting := range n.accepting {
+			if accepting && bits[state] >= 0 {
+				b.accepting |= 1 << uint(bits[state])
+			}
+		}
+
+		return b, nil
+	}
+
+	// The closure of a byte of the mask is the union of the closures of its bits.
+	stateClosure := make([]uint64, len(n.outputs))
+	for state := range n.outputs {
+		for _, reached := range n.closure([]int{state}) {
+			stateClosure[state] |= 1 << uint(reached)
+		}
+	}
+
+	for chunk := 0; chunk < 8; chunk++ {
+		for value := 0; value < 256; value++ {
+			var mask uint64
+			for bit := 0; bit < 8; bit++ {
+				state := chunk*8 + bit
+				if value&(1<<uint(bit)) != 0 && state < len(n.outputs) {
+					mask |= stateClosure[state]
+				}
+			}
+			b.closure[chunk][value] = mask
+		}
+	}
+
+	b.initial = stateClosure[n.initialState]
+	for state, accepting := range n.accepting {
+		if accepting {
+			b.accepting |= 1 << uint(state)
+		}
+	}
+
+	return b, nil
+}
+
+// Function to check if the input is accepted
+//   - returns false for undefined inputs, like Accepts
+func (b *BitParallelNFA) Accepts(input string) bool {
+	active, started := b.initial, !b.implicitInitial
+	for _, char := range input {
+		symbol, ok := b.symbols[char]
+		if !ok {
+			return false
+		}
+
+		if started {
+			active = b.step(active, symbol)
+		} else {
+			active, started = b.first[symbol], true
+		}
+		if active == 0 {
+			return false
+		}
+	}
+
+	if !started {
+		return b.initialAccepting
+	}
+
+	return active&b.accepting != 0
+}
+
+// step returns the mask of states active after reading the symbol
+func (b *BitParallelNFA) step(active uint64, symbol int) uint64 {
+	var next uint64
+	for k, shift := range b.shifts {
+		moving := active & b.masks[symbol][k]
+		if moving == 0 {
+			continue
+		}
+
+		if shift >= 0 {
+			next |= moving << uint(shift)
+		} else {
+			next |= moving >> uint(-shift)
+		}
+	}
+
+	if !b.hasEpsilon || next == 0 {
+		return next
+	}
+
+	var closed uint64
+	for chunk := 0; next != 0; chunk++ {
+		closed |= b.closure[chunk][next&0xff]
+		next >>= 8
+	}
+
+	return closed
+}
@@ -0,0 +1,117 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestBitParallel_Accepts tests that the bit-parallel simulation agrees with
+// the set simulation.
+func TestBitParallel_Accepts(t *testing.T) {
+	n := GetMockContainsNFA("1101")
+
+	b, err := n.BitParallel()
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	for _, input := range append(binaryStrings(9), "1102") {
+		if b.Accepts(input) != n.Accepts(input) {
+			t.Errorf("Expected %t for input %s", n.Accepts(input), input)
+		}
+	}
+}
+
+// TestBitParallel_EpsilonTransitions tests that epsilon closures are applied.
+func TestBitParallel_EpsilonTransitions(t *testing.T) {
+	n := NewNFA(map[string]bool{"a": true, "b": true})
+	start := n.AddState("start", false)
+	a := n.AddState("a", false)
+	b := n.AddState("b", true)
+	n.AddTransition(start, "a", a)
+	n.AddTransition(b, "b", start)
+	n.AddEpsilonTransition(a, start)
+	n.AddEpsilonTransition(start, b)
+
+	bp, err := n.BitParallel()
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	for _, input := range []string{"", "a", "ab", "aba", "bb", "abba", "c"} {
+		if bp.Accepts(input) != n.Accepts(input) {
+			t.Errorf("Expected %t for input %s", n.Accepts(input), input)
+		}
+	}
+}
+
+// TestBitParallel_ErrorTooManyStates tests that NFAs over 64 states are rejected.
+func TestBitParallel_ErrorTooManyStates(t *testing.T) {
+	n := GetMockContainsNFA(strings.Repeat("10", 32))
+
+	_, err := n.BitParallel()
+
+	if err == nil {
+		t.Errorf("Expected error for too many states, got nil")
+	}
+}
+
+// TestBitParallel_Glushkov tests that the initial state of a Glushkov NFA does not
+// take a bit, so 64 positions fit.
+func TestBitParallel_Glushkov(t *testing.T) {
+	bit := PositionSymbols("0", "1")
+	for _, expr := range []*PositionExpr{
+		PositionConcat(PositionStar(bit), PositionLiteral("1"), bit, bit),
+		PositionConcat(PositionPlus(PositionLiteral("1")), PositionStar(PositionLiteral("01"))),
+		PositionConcat(PositionOptional(PositionLiteral("0")), PositionStar(PositionLiteral("1"))),
+		PositionEmpty(),
+	} {
+		n, _ := NewGlushkovNFA(expr, "")
+
+		b, err := n.BitParallel()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+
+		for _, input := range append(binaryStrings(9), "1x") {
+			if b.Accepts(input) != n.Accepts(input) {
+				t.Errorf("Expected %t for input %s", n.Accepts(input), input)
+			}
+		}
+	}
+
+	literal := strings.Repeat("ab", 32)
+	n, _ := NewGlushkovNFA(PositionLiteral(literal), "")
+
+	b, err := n.BitParallel()
+	if err != nil {
+		t.Fatalf("Expected nil error for 64 positions, got %v", err)
+	}
+
+	if !b.Accepts(literal) || b.Accepts(literal[1:]) || b.Accepts(literal+"a") {
+		t.Errorf("Expected only %s to be accepted", literal)
+	}
+
+	n, _ = NewGlushkovNFA(PositionLiteral(literal+"a"), "")
+	if _, err := n.BitParallel(); err == nil {
+		t.Errorf("Expected error for 65 positions, got nil")
+	}
+}
+
+func BenchmarkNFA_Accepts(b *testing.B) {
+	n := GetMockContainsNFA("110111")
+
+	for i := 0; i < b.N; i++ {
+		n.Accepts(benchmarkInput)
+	}
+}
+
+func BenchmarkBitParallelNFA_Accepts(b *testing.B) {
+	n := GetMockContainsNFA("110111")
+	bp, _ := n.BitParallel()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		bp.Accepts(benchmarkInput)
+	}
+}
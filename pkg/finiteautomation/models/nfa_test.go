@@ -0,0 +1,100 @@
+package models
+
+import "testing"
+
+// GetMockContainsNFA returns an NFA over {0, 1} accepting the strings that
+// contain the given pattern, as a chain of positions with a self loop on the
+// first state.
+func GetMockContainsNFA(pattern string) *NFA {
+	n := NewNFA(map[string]bool{"0": true, "1": true})
+	previous := n.AddState("start", len(pattern) == 0)
+	n.AddTransition(previous, "0", previous)
+	n.AddTransition(previous, "1", previous)
+
+	for i, char := range pattern {
+		next := n.AddState(pattern[:i+1], i == len(pattern)-1)
+		n.AddTransition(previous, string(char), next)
+		previous = next
+	}
+
+	n.AddTransition(previous, "0", previous)
+	n.AddTransition(previous, "1", previous)
+
+	return n
+}
+
+// TestNFA_Accepts tests that the NFA simulation accepts strings containing the pattern.
+func TestNFA_Accepts(t *testing.T) {
+	n := GetMockContainsNFA("101")
+
+	for input, expected := range map[string]bool{"101": true, "0010100": true, "1001": false, "": false, "12": false} {
+		if n.Accepts(input) != expected {
+			t.Errorf("Expected %t for input %s", expected, input)
+		}
+	}
+}
+
+// TestNFA_EpsilonTransitions tests that epsilon transitions are followed.
+func TestNFA_EpsilonTransitions(t *testing.T) {
+	n := NewNFA(map[string]bool{"a": true, "b": true})
+	start := n.AddState("start", false)
+	a := n.AddState("a", false)
+	b := n.AddState("b", true)
+	n.AddTransition(start, "a", a)
+	n.AddEpsilonTransition(a, start)
+	n.AddEpsilonTransition(start, b)
+
+	for input, expected := range map[string]bool{"": true, "a": true, "aaa": true, "b": false} {
+		if n.Accepts(input) != expected {
+			t.Errorf("Expected %t for input %s", expected, input)
+		}
+	}
+}
+
+// TestNFA_Determinize tests that the subset construction accepts the same strings.
+func TestNFA_Determinize(t *testing.T) {
+	n := GetMockContainsNFA("101")
+
+	fa, err := n.Determinize()
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	for _, input := range binaryStrings(8) {
+		_, err := fa.Compute(input)
+
+		if (err == nil) != n.Accepts(input) {
+			t.Errorf("Expected %t for input %s", n.Accepts(input), input)
+		}
+	}
+}
+
+// TestNFA_ErrorInvalidTransition tests that transitions on undefined inputs are rejected.
+func TestNFA_ErrorInvalidTransition(t *testing.T) {
+	n := NewNFA(map[string]bool{"0": true})
+	state := n.AddState("0", true)
+
+	err := n.AddTransition(state, "1", state)
+
+	if err == nil {
+		t.Errorf("Expected error for invalid transition, got nil")
+	}
+}
+
+// TestToNFA_NoError tests that converting a FiniteAutomation keeps its language.
+func TestToNFA_NoError(t *testing.T) {
+	fa := GetMockFiniteAutomation()
+
+	n, err := fa.ToNFA()
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	for _, input := range binaryStrings(6) {
+		_, err := fa.Compute(input)
+
+		if (err == nil) != n.Accepts(input) {
+			t.Errorf("Expected %t for input %s", err == nil, input)
+		}
+	}
+}
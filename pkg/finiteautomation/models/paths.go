@@ -0,0 +1,171 @@
+package models
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+)
+
+// Function to find the shortest accepted word
+//   - breadth-first search over the sorted input symbols, so the word is also the
+//     lexicographically smallest among the shortest
+//   - guarded transitions count as possible, their guards are not evaluated
+//   - returns false if no word is accepted
+func (fa *FiniteAutomation) ShortestAccepted() (string, bool) {
+	if fa == nil || fa.states == nil || fa.initialState == nil {
+		return "", false
+	}
+
+	previous := fa.breadthFirst(fa.initialState)
+	for _, state := range fa.orderedStates() {
+		if _, reached := previous[state]; reached && fa.acceptingStates[state] {
+			return concat(pathTo(previous, state)), true
+		}
+	}
+
+	return "", false
+}
+
+// Function to find the shortest sequence of input symbols leading from one state to another
+//   - same rules as ShortestAccepted
+//   - returns an empty path if both states are the same, nil if to is unreachable
+func (fa *FiniteAutomation) ShortestPath(from *State, to *State) []string {
+	if fa == nil || fa.states == nil || fa.states[from] == nil || fa.states[to] == nil {
+		return nil
+	}
+
+	previous := fa.breadthFirst(from)
+	if _, reached := previous[to]; !reached {
+		return nil
+	}
+
+	return pathTo(previous, to)
+}
+
+// Function to find the cheapest sequence of input symbols leading from one state
+// to another, using the cost of every input symbol (Dijkstra)
+//   - returns the path and its total cost
+//   - returns an error if a cost is missing or negative, or to is unreachable
+func (fa *FiniteAutomation) ShortestWeightedPath(from *State, to *State, costs map[string]float64) ([]string, float64, error) {
+	if fa == nil || fa.states == nil || fa.states[from] == nil || fa.states[to] == nil {
+		return nil, 0, errors.New(fmt.Sprintln("Invalid state - not in the set of states"))
+	}
+
+	for input := range fa.inputs {
+		cost, ok := costs[input]
+		if !ok || cost < 0 {
+			return nil, 0, errors.New(fmt.Sprintln("Invalid cost for input: ", input))
+		}
+	}
+
+	distance := map[*State]float64{from: 0}
+	previous := map[*State]pathStep{from: {}}
+	done := map[*State]bool{}
+	queue := &pathQueue{{state: from}}
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(pathItem)
+		if done[item.state] {
+			continue
+		}
+		done[item.state] = true
+
+		if item.state == to {
+			return pathTo(previous, to), item.distance, nil
+		}
+
+		for _, edge := range fa.edges(item.state) {
+			next := item.distance + costs[edge.input]
+			if d, ok := distance[edge.state]; !ok || next < d {
+				distance[edge.state] = next
+				previous[edge.state] = pathStep{from: item.state, input: edge.input}
+				heap.Push(queue, pathItem{state: edge.state, distance: next})
+			}
+		}
+	}
+
+	return nil, 0, errors.New(fmt.Sprintln("Invalid path - state not reachable: ", to.GetOutput()))
+}
+
+// pathStep is the last transition of the best known path to a state
+type pathStep struct {
+	from  *State
+	input string
+}
+
+// pathEdge is a transition leaving a state
+type pathEdge struct {
+	input string
+	state *State
+}
+
+// edges returns the transitions leaving the state over the sorted input symbols,
+// guarded transitions included
+func (fa *FiniteAutomation) edges(state *State) []pathEdge {
+	edges := []pathEdge{}
+	for _, input := range fa.sortedInputs() {
+		for _, transitionFunction := range state.guardedTransitions[input] {
+			edges = append(edges, pathEdge{input, transitionFunction.transitionState})
+		}
+
+		if next, ok := state.transition[input]; ok {
+			edges = append(edges, pathEdge{input, next})
+		}
+	}
+
+	return edges
+}
+
+// breadthFirst returns the last transition of the shortest path to every state
+// reachable from the start
+func (fa *FiniteAutomation) breadthFirst(start *State) map[*State]pathStep {
+	previous := map[*State]pathStep{start: {}}
+	queue := []*State{start}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		for _, edge := range fa.edges(state) {
+			if _, seen := previous[edge.state]; !seen {
+				previous[edge.state] = pathStep{from: state, input: edge.input}
+				queue = append(queue, edge.state)
+			}
+		}
+	}
+
+	return previous
+}
+
+// pathTo follows the recorded steps back from the state - returns the input symbols
+func pathTo(previous map[*State]pathStep, state *State) []string {
+	path := []string{}
+	for previous[state].from != nil {
+		path = append(path, previous[state].input)
+		state = previous[state].from
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path
+}
+
+// pathItem is a state waiting in the Dijkstra queue
+type pathItem struct {
+	state    *State
+	distance float64
+}
+
+// pathQueue is a min-heap of pathItems by distance
+type pathQueue []pathItem
+
+func (q pathQueue) Len() int            { return len(q) }
+func (q pathQueue) Less(i, j int) bool  { return q[i].distance < q[j].distance }
+func (q pathQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *pathQueue) Push(x interface{}) { *q = append(*q, x.(pathItem)) }
+func (q *pathQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+
+	return item
+}
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestShortestAccepted_NoError tests that the shortest accepted word is found.
+func TestShortestAccepted_NoError(t *testing.T) {
+	fa := GetMockModuloThreeFiniteAutomation()
+	fa.acceptingStates = map[*State]bool{}
+	for state := range fa.states {
+		if state.GetOutput() == "2" {
+			fa.acceptingStates[state] = true
+		}
+	}
+
+	word, ok := fa.ShortestAccepted()
+
+	if !ok || word != "10" {
+		t.Errorf("Expected %s, got %s %t", "10", word, ok)
+	}
+}
+
+// TestShortestAccepted_NoWord tests that no word is reported when none is accepted.
+func TestShortestAccepted_NoWord(t *testing.T) {
+	fa := GetMockOnesFiniteAutomation()
+	fa.acceptingStates = map[*State]bool{}
+
+	_, ok := fa.ShortestAccepted()
+
+	if ok {
+		t.Errorf("Expected no accepted word")
+	}
+}
+
+// TestShortestPath_NoError tests the shortest path between two states,
+// through guarded transitions too.
+func TestShortestPath_NoError(t *testing.T) {
+	fa := GetMockGuardedFiniteAutomation()
+	var pending, paid *State
+	for state := range fa.states {
+		switch state.GetOutput() {
+		case "Pending":
+			pending = state
+		case "Paid":
+			paid = state
+		}
+	}
+
+	if path := fa.ShortestPath(pending, paid); !reflect.DeepEqual(path, []string{"P"}) {
+		t.Errorf("Expected [P], got %v", path)
+	}
+
+	if path := fa.ShortestPath(paid, pending); path != nil {
+		t.Errorf("Expected nil path, got %v", path)
+	}
+
+	if path := fa.ShortestPath(paid, paid); path == nil || len(path) != 0 {
+		t.Errorf("Expected empty path, got %v", path)
+	}
+}
+
+// TestShortestWeightedPath_NoError tests that the cheapest path is preferred
+// over the shortest one.
+func TestShortestWeightedPath_NoError(t *testing.T) {
+	fa := GetMockModuloThreeFiniteAutomation()
+	var zero, two *State
+	for state := range fa.states {
+		switch state.GetOutput() {
+		case "0":
+			zero = state
+		case "2":
+			two = state
+		}
+	}
+
+	path, cost, err := fa.ShortestWeightedPath(zero, two, map[string]float64{"0": 10, "1": 1})
+
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	// every path to remainder 2 reads a 0, "10" is the only one reading a single 0
+	if !reflect.DeepEqual(path, []string{"1", "0"}) || cost != 11 {
+		t.Errorf("Expected [1 0] with cost 11, got %v with cost %v", path, cost)
+	}
+}
+
+// TestShortestWeightedPath_ErrorCost tests that missing or negative costs are rejected.
+func TestShortestWeightedPath_ErrorCost(t *testing.T) {
+	fa := GetMockModuloThreeFiniteAutomation()
+
+	_, _, err := fa.ShortestWeightedPath(fa.initialState, fa.initialState, map[string]float64{"0": 1, "1": -1})
+
+	if err == nil {
+		t.Errorf("Expected error for negative cost, got nil")
+	}
+}
@@ -0,0 +1,515 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// maxExpandedRange is the size up to which a character range adds all of its
+// runes to the alphabet, larger ranges only match runes already in the alphabet
+const maxExpandedRange = 256
+
+// regexKind is the kind of a node of a parsed regular expression
+type regexKind int
+
+const (
+	regexEmpty regexKind = iota
+	regexChars
+	regexConcat
+	regexUnion
+	regexStar
+	regexPlus
+	regexOptional
+	regexIntersect
+	regexComplement
+)
+
+// regexNode is a node of a parsed regular expression.
+// It contains:
+//   - kind: the kind of node.
+//   - ranges: for regexChars, the inclusive rune ranges matched, or not matched
+//     when negated is set.
+//   - children: the operands of concatenation, union, intersection, complement
+//     and repetitions.
+type regexNode struct {
+	kind     regexKind
+	ranges   [][2]rune
+	negated  bool
+	children []*regexNode
+}
+
+// Function to compile a regular expression into an NFA (Thompson construction)
+//   - supported syntax: literals, escapes (\n \t \r \d \w \s and escaped
+//     metacharacters), '.', classes like [a-z_] and [^0-9], groups, '|', '*', '+', '?'
+//   - '&' and '~' are literal runes, they are operators only in ParseRegex
+//   - the input symbols are the runes of the pattern plus printable ASCII, tab,
+//     newline and carriage return; '.' and negated classes match within these
+//   - the accepting state outputs the pattern
+func CompileRegex(pattern string) (*NFA, error) {
+	node, err := parseRegex(pattern, false)
+	if err != nil {
+		return nil, err
+	}
+
+	alphabet := defaultRegexAlphabet()
+	node.collectAlphabet(alphabet)
+
+	n := NewNFA(alphabet)
+	start, end := node.addTo(n, sortedRunes(alphabet))
+	n.accepting[end] = true
+	n.outputs[end] = pattern
+	n.initialState = start
+
+	return n, nil
+}
+
+// Function to compile a regular expression into its position NFA (Glushkov
+// construction)
+//   - same syntax and input symbols as CompileRegex
+//   - the pattern is turned into a PositionExpr whose positions are its rune
+//     classes, so the NFA built by NewGlushkovNFA has one state per rune class
+//     plus the initial state
+//   - the accepting states output the pattern
+func CompileRegexGlushkov(pattern string) (*NFA, error) {
+	node, err := parseRegex(pattern, false)
+	if err != nil {
+		return nil, err
+	}
+
+	alphabet := defaultRegexAlphabet()
+	node.collectAlphabet(alphabet)
+
+	n, err := NewGlushkovNFA(node.toPositions(sortedRunes(alphabet)), pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	for input := range alphabet {
+		n.inputs[input] = true
+	}
+
+	return n, nil
+}
+
+// defaultRegexAlphabet returns printable ASCII plus tab, newline and carriage return
+func defaultRegexAlphabet() map[string]bool {
+	alphabet := map[string]bool{"\t": true, "\n": true, "\r": true}
+	for char := rune(0x20); char < 0x7f; char++ {
+		alphabet[string(char)] = true
+	}
+
+	return alphabet
+}
+
+// sortedRunes returns the single-rune symbols of the alphabet in increasing order
+func sortedRunes(alphabet map[string]bool) []rune {
+	runes := []rune{}
+	for input := range alphabet {
+		char, size := utf8.DecodeRuneInString(input)
+		if size == len(input) && char != utf8.RuneError {
+			runes = append(runes, char)
+		}
+	}
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+
+	return runes
+}
+
+// collectAlphabet adds the runes of the non-negated ranges to the alphabet
+func (re *regexNode) collectAlphabet(alphabet map[string]bool) {
+	if re.kind == regexChars && !re.negated {
+		for _, r := range re.ranges {
+			if r[1]-r[0] < maxExpandedRange {
+				for char := r[0]; char <= r[1]; char++ {
+					alphabet[string(char)] = true
+				}
+			}
+		}
+	}
+
+	for _, child := range re.children {
+		child.collectAlphabet(alphabet)
+	}
+}
+
+// collectBoundaries adds the first rune of every range and the rune after it to
+// the boundaries, negated ranges included
+func (re *regexNode) collectBoundaries(boundaries map[rune]bool) {
+	if re.kind == regexChars {
+		for _, r := range re.ranges {
+			boundaries[r[0]], boundaries[r[1]+1] = true, true
+		}
+	}
+
+	for _, child := range re.children {
+		child.collectBoundaries(boundaries)
+	}
+}
+
+// toPositions converts the node into a PositionExpr, a regexChars node becoming
+// a position matching its runes of the alphabet
+func (re *regexNode) toPositions(runes []rune) *PositionExpr {
+	children := make([]*PositionExpr, len(re.children))
+	for i, child := range re.children {
+		children[i] = child.toPositions(runes)
+	}
+
+	switch re.kind {
+	case regexChars:
+		symbols := []string{}
+		for _, char := range runes {
+			if re.matches(char) {
+				symbols = append(symbols, string(char))
+			}
+		}
+		return PositionSymbols(symbols...)
+	case regexConcat:
+		return PositionConcat(children...)
+	case regexUnion:
+		return PositionUnion(children...)
+	case regexStar:
+		return PositionStar(children[0])
+	case regexPlus:
+		return PositionPlus(children[0])
+	case regexOptional:
+		return PositionOptional(children[0])
+	default:
+		return PositionEmpty()
+	}
+}
+
+// matches checks if the rune is matched by a regexChars node
+func (re *regexNode) matches(char rune) bool {
+	for _, r := range re.ranges {
+		if char >= r[0] && char <= r[1] {
+			return !re.negated
+		}
+	}
+
+	return re.negated
+}
+
+// addTo adds the Thompson fragment of the node to the NFA - returns its start
+// and end states, the end state has no outgoing transition
+func (re *regexNode) addTo(n *NFA, alphabet []rune) (int, int) {
+	switch re.kind {
+	case regexChars:
+		start, end := n.AddState("", false), n.AddState("", false)
+		for _, char := range alphabet {
+			if re.matches(char) {
+				n.AddTransition(start, string(char), end)
+			}
+		}
+		return start, end
+	case regexConcat:
+		start, end := re.children[0].addTo(n, alphabet)
+		for _, child := range re.children[1:] {
+			childStart, childEnd := child.addTo(n, alphabet)
+			n.AddEpsilonTransition(end, childStart)
+			end = childEnd
+		}
+		return start, end
+	case regexUnion:
+		start, end := n.AddState("", false), n.AddState("", false)
+		for _, child := range re.children {
+			childStart, childEnd := child.addTo(n, alphabet)
+			n.AddEpsilonTransition(start, childStart)
+			n.AddEpsilonTransition(childEnd, end)
+		}
+		return start, end
+	case regexStar, regexPlus, regexOptional:
+		start, end := n.AddState("", false), n.AddState("", false)
+		childStart, childEnd := re.children[0].addTo(n, alphabet)
+		n.AddEpsilonTransition(start, childStart)
+		n.AddEpsilonTransition(childEnd, end)
+		if re.kind != regexPlus {
+			n.AddEpsilonTransition(start, end)
+		}
+		if re.kind != regexOptional {
+			n.AddEpsilonTransition(childEnd, childStart)
+		}
+		return start, end
+	default:
+		state := n.AddState("", false)
+		return state, state
+	}
+}
+
+// regexParser is a recursive descent parser over the runes of a pattern
+//   - extended: whether '&' and '~' are the intersection and complement
+//     operators, otherwise they are literal runes
+type regexParser struct {
+	pattern  []rune
+	pos      int
+	extended bool
+}
+
+// parseRegex parses the pattern into its syntax tree
+func parseRegex(pattern string, extended bool) (*regexNode, error) {
+	p := &regexParser{pattern: []rune(pattern), extended: extended}
+	node, err := p.parseUnion()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.pattern) {
+		return nil, p.errorf("unexpected %q", p.pattern[p.pos])
+	}
+
+	return node, nil
+}
+
+func (p *regexParser) errorf(format string, args ...interface{}) error {
+	return errors.New(fmt.Sprintln("Invalid regex at position ", p.pos, " - ", fmt.Sprintf(format, args...)))
+}
+
+func (p *regexParser) peek() (rune, bool) {
+	if p.pos >= len(p.pattern) {
+		return 0, false
+	}
+
+	return p.pattern[p.pos], true
+}
+
+// parseUnion parses alternatives separated by '|'
+func (p *regexParser) parseUnion() (*regexNode, error) {
+	alternatives := []*regexNode{}
+	for {
+		node, err := p.parseIntersect()
+		if err != nil {
+			return nil, err
+		}
+		alternatives = append(alternatives, node)
+
+		if char, ok := p.peek(); !ok || char != '|' {
+			break
+		}
+		p.pos++
+	}
+
+	if len(alternatives) == 1 {
+		return alternatives[0], nil
+	}
+
+	return &regexNode{kind: regexUnion, children: alternatives}, nil
+}
+
+// parseIntersect parses operands separated by '&', which binds tighter than '|',
+// when the parser is extended
+func (p *regexParser) parseIntersect() (*regexNode, error) {
+	operands := []*regexNode{}
+	for {
+		node, err := p.parseConcat()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, node)
+
+		if char, ok := p.peek(); !ok || char != '&' || !p.extended {
+			break
+		}
+		p.pos++
+	}
+
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+
+	return &regexNode{kind: regexIntersect, children: operands}, nil
+}
+
+// parseConcat parses a sequence of repeated atoms
+func (p *regexParser) parseConcat() (*regexNode, error) {
+	items := []*regexNode{}
+	for {
+		char, ok := p.peek()
+		if !ok || char == '|' || char == ')' || (char == '&' && p.extended) {
+			break
+		}
+
+		node, err := p.parseRepeat()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, node)
+	}
+
+	switch len(items) {
+	case 0:
+		return &regexNode{kind: regexEmpty}, nil
+	case 1:
+		return items[0], nil
+	default:
+		return &regexNode{kind: regexConcat, children: items}, nil
+	}
+}
+
+// parseRepeat parses an atom followed by any number of '*', '+' and '?', or the
+// complement '~' of such an expression when the parser is extended
+func (p *regexParser) parseRepeat() (*regexNode, error) {
+	if char, ok := p.peek(); ok && char == '~' && p.extended {
+		p.pos++
+		node, err := p.parseRepeat()
+		if err != nil {
+			return nil, err
+		}
+
+		return &regexNode{kind: regexComplement, children: []*regexNode{node}}, nil
+	}
+
+	node, err := p.parseAtom()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		char, ok := p.peek()
+		if !ok {
+			return node, nil
+		}
+
+		switch char {
+		case '*':
+			node = &regexNode{kind: regexStar, children: []*regexNode{node}}
+		case '+':
+			node = &regexNode{kind: regexPlus, children: []*regexNode{node}}
+		case '?':
+			node = &regexNode{kind: regexOptional, children: []*regexNode{node}}
+		default:
+			return node, nil
+		}
+		p.pos++
+	}
+}
+
+// parseAtom parses a literal, an escape, '.', a class or a group
+func (p *regexParser) parseAtom() (*regexNode, error) {
+	char, _ := p.peek()
+	switch char {
+	case '(':
+		p.pos++
+		node, err := p.parseUnion()
+		if err != nil {
+			return nil, err
+		}
+		if next, ok := p.peek(); !ok || next != ')' {
+			return nil, p.errorf("missing ')'")
+		}
+		p.pos++
+		return node, nil
+	case '[':
+		return p.parseClass()
+	case '.':
+		p.pos++
+		return &regexNode{kind: regexChars, ranges: [][2]rune{{'\n', '\n'}}, negated: true}, nil
+	case '*', '+', '?':
+		return nil, p.errorf("missing operand for %q", char)
+	case '\\':
+		p.pos++
+		return p.parseEscape()
+	default:
+		p.pos++
+		return &regexNode{kind: regexChars, ranges: [][2]rune{{char, char}}}, nil
+	}
+}
+
+// parseEscape parses the rune following a backslash
+func (p *regexParser) parseEscape() (*regexNode, error) {
+	char, ok := p.peek()
+	if !ok {
+		return nil, p.errorf("trailing '\\'")
+	}
+	p.pos++
+
+	if ranges, ok := classEscapes[char]; ok {
+		return &regexNode{kind: regexChars, ranges: append([][2]rune{}, ranges...)}, nil
+	}
+
+	char = unescapeRune(char)
+	return &regexNode{kind: regexChars, ranges: [][2]rune{{char, char}}}, nil
+}
+
+// classEscapes are the ranges of the \d, \w and \s escapes, inside and outside classes
+var classEscapes = map[rune][][2]rune{
+	'd': {{'0', '9'}},
+	'w': {{'0', '9'}, {'A', 'Z'}, {'_', '_'}, {'a', 'z'}},
+	's': {{'\t', '\n'}, {'\r', '\r'}, {' ', ' '}},
+}
+
+// parseClass parses a bracketed character class
+func (p *regexParser) parseClass() (*regexNode, error) {
+	p.pos++
+	node := &regexNode{kind: regexChars}
+	if char, ok := p.peek(); ok && char == '^' {
+		node.negated = true
+		p.pos++
+	}
+
+	for first := true; ; first = false {
+		char, ok := p.peek()
+		if !ok {
+			return nil, p.errorf("missing ']'")
+		}
+		if char == ']' && !first {
+			p.pos++
+			return node, nil
+		}
+		p.pos++
+
+		isRange := func() bool {
+			next, ok := p.peek()
+			return ok && next == '-' && p.pos+1 < len(p.pattern) && p.pattern[p.pos+1] != ']'
+		}
+
+		if char == '\\' {
+			escaped, ok := p.peek()
+			if !ok {
+				return nil, p.errorf("trailing '\\'")
+			}
+			p.pos++
+
+			if ranges, ok := classEscapes[escaped]; ok {
+				if isRange() {
+					return nil, p.errorf("invalid range from \\%c", escaped)
+				}
+				node.ranges = append(node.ranges, ranges...)
+				continue
+			}
+			char = unescapeRune(escaped)
+		}
+
+		low, high := char, char
+		if isRange() {
+			p.pos++
+			high = p.pattern[p.pos]
+			p.pos++
+			if high == '\\' && p.pos < len(p.pattern) {
+				if _, ok := classEscapes[p.pattern[p.pos]]; ok {
+					return nil, p.errorf("invalid range to \\%c", p.pattern[p.pos])
+				}
+				high = unescapeRune(p.pattern[p.pos])
+				p.pos++
+			}
+			if high < low {
+				return nil, p.errorf("invalid range %q-%q", low, high)
+			}
+		}
+		node.ranges = append(node.ranges, [2]rune{low, high})
+	}
+}
+
+// unescapeRune returns the rune an escaped character stands for
+func unescapeRune(char rune) rune {
+	switch char {
+	case 'n':
+		return '\n'
+	case 't':
+		return '\t'
+	case 'r':
+		return '\r'
+	default:
+		return char
+	}
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"regexp"
+	"testing"
+)
+
+// TestCompileRegex_Accepts tests that the compiled NFA accepts the language of the pattern.
+func TestCompileRegex_Accepts(t *testing.T) {
+	for pattern, cases := range map[string]map[string]bool{
+		"a(b|c)*d":           {"ad": true, "abcbd": true, "abd": true, "ab": false, "aed": false},
+		"[0-9]+(\\.[0-9]+)?": {"42": true, "3.14": true, "3.": false, ".5": false},
+		"[^a-c]x?":           {"d": true, "dx": true, "a": false, "": false},
+		"\\w+@\\w+":          {"me@host": true, "@host": false},
+		"a.c":                {"abc": true, "a c": true, "a\nc": false},
+		"":                   {"": true, "a": false},
+		"a&b|~c":             {"a&b": true, "~c": true, "ab": false, "c": false},
+	} {
+		n, err := CompileRegex(pattern)
+		if err != nil {
+			t.Fatalf("Expected nil error for %s, got %v", pattern, err)
+		}
+
+		for input, expected := range cases {
+			if n.Accepts(input) != expected {
+				t.Errorf("Expected %t for pattern %s and input %q", expected, pattern, input)
+			}
+		}
+	}
+}
+
+// TestCompileRegex_ClassEscapes tests that \d, \w and \s inside classes match
+// their ranges, like in regexp.
+func TestCompileRegex_ClassEscapes(t *testing.T) {
+	inputs := []string{"", "\t", "\n", "\r", "\t5", "a-", "__", "09", "z z"}
+	for char := rune(0x20); char < 0x7f; char++ {
+		inputs = append(inputs, string(char))
+	}
+
+	for _, pattern := range []string{`[\d]`, `[\w-]`, `[^\s]`, `[\d\s]+`, `[a\d-]*`, `[^\w\s]`, `[\]\d]`} {
+		n, err := CompileRegex(pattern)
+		if err != nil {
+			t.Fatalf("Expected nil error for %s, got %v", pattern, err)
+		}
+
+		re := regexp.MustCompile("^(" + pattern + ")$")
+		for _, input := range inputs {
+			if n.Accepts(input) != re.MatchString(input) {
+				t.Errorf("Expected %t for pattern %s and input %q", re.MatchString(input), pattern, input)
+			}
+		}
+	}
+}
+
+// TestCompileRegex_Error tests that invalid patterns are rejected.
+func TestCompileRegex_Error(t *testing.T) {
+	for _, pattern := range []string{"(ab", "ab)", "*a", "[a-", "[z-a]", "a\\", "[\\d-z]", "[a-\\w]"} {
+		_, err := CompileRegex(pattern)
+
+		if err == nil {
+			t.Errorf("Expected error for pattern %s, got nil", pattern)
+		}
+	}
+}
+
+// TestCompileRegexGlushkov_Accepts tests that the position NFA accepts the
+// language of the pattern with one state per rune class plus the initial state.
+func TestCompileRegexGlushkov_Accepts(t *testing.T) {
+	for pattern, positions := range map[string]int{
+		"1+(01)*":        3,
+		"(0|1)*1(0|1)?":  5,
+		"[01]*1[01]?":    3,
+		"0?1*0?":         3,
+		"(00|1)*0":       4,
+		"((0|1)(0|1))*1": 5,
+		"":               0,
+	} {
+		n, err := CompileRegexGlushkov(pattern)
+		if err != nil {
+			t.Fatalf("Expected nil error for %s, got %v", pattern, err)
+		}
+
+		if n.NumStates() != positions+1 {
+			t.Errorf("Expected %d states for pattern %s, got %d", positions+1, pattern, n.NumStates())
+		}
+
+		re := regexp.MustCompile("^(" + pattern + ")$")
+		for _, input := range append(binaryStrings(7), "2") {
+			if n.Accepts(input) != re.MatchString(input) {
+				t.Errorf("Expected %t for pattern %s and input %s", re.MatchString(input), pattern, input)
+			}
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// RuneSet is a set of runes stored as sorted, disjoint and non-adjacent inclusive
+// ranges, so intersection, union, complement and emptiness are decidable.
+// RuneSets are values, the operations never modify their operands.
+type RuneSet struct {
+	ranges [][2]rune
+}
+
+// Function to get the set of the runes between low and high inclusive
+func RuneRange(low rune, high rune) RuneSet {
+	return newRuneSet([][2]rune{{low, high}})
+}
+
+// Function to get the set of the given runes
+func RuneOf(runes ...rune) RuneSet {
+	ranges := make([][2]rune, len(runes))
+	for i, char := range runes {
+		ranges[i] = [2]rune{char, char}
+	}
+
+	return newRuneSet(ranges)
+}
+
+// Function to get the set of every rune up to unicode.MaxRune
+func AnyRune() RuneSet {
+	return RuneSet{ranges: [][2]rune{{0, unicode.MaxRune}}}
+}
+
+// Function to get the set of the runes of a Unicode range table
+func RuneTable(table *unicode.RangeTable) RuneSet {
+	ranges := [][2]rune{}
+	for _, r := range table.R16 {
+		ranges = appendStride(ranges, rune(r.Lo), rune(r.Hi), rune(r.Stride))
+	}
+	for _, r := range table.R32 {
+		ranges = appendStride(ranges, rune(r.Lo), rune(r.Hi), rune(r.Stride))
+	}
+
+	return newRuneSet(ranges)
+}
+
+// Function to get the set of a Unicode category or script by name, like "Lu" or
+// "Greek"
+func RuneCategory(name string) (RuneSet, error) {
+	if table, ok := unicode.Categories[name]; ok {
+		return RuneTable(table), nil
+	}
+
+	if table, ok := unicode.Scripts[name]; ok {
+		return RuneTable(table), nil
+	}
+
+	return RuneSet{}, errors.New(fmt.Sprintln("Invalid category - Unknown Unicode category or script", name))
+}
+
+// Function to get the set of the runes satisfying a predicate
+//   - the predicate is evaluated once on every rune up to unicode.MaxRune, so the
+//     resulting set supports the same decidable operations as ranges
+func RunePredicate(predicate func(rune) bool) RuneSet {
+	ranges := [][2]rune{}
+	for char := rune(0); char <= unicode.MaxRune; char++ {
+		if !predicate(char) {
+			continue
+		}
+
+		if last := len(ranges) - 1; last >= 0 && ranges[last][1] == char-1 {
+			ranges[last][1] = char
+		} else {
+			ranges = append(ranges, [2]rune{char, char})
+		}
+	}
+
+	return RuneSet{ranges: ranges}
+}
+
+// appendStride appends the ranges of the runes from low to high by stride
+func appendStride(ranges [][2]rune, low rune, high rune, stride rune) [][2]rune {
+	if stride == 1 {
+		return append(ranges, [2]rune{low, high})
+	}
+
+	for char := low; char <= high; char += stride {
+		ranges = append(ranges, [2]rune{char, char})
+	}
+
+	return ranges
+}
+
+// newRuneSet sorts, clamps and merges the ranges
+func newRuneSet(ranges [][2]rune) RuneSet {
+	sorted := make([][2]rune, 0, len(ranges))
+	for _, r := range ranges {
+		if r[0] < 0 {
+			r[0] = 0
+		}
+		if r[1] > unicode.MaxRune {
+			r[1] = unicode.MaxRune
+		}
+		if r[0] <= r[1] {
+			sorted = append(sorted, r)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i][0] < sorted[j][0] })
+
+	merged := [][2]rune{}
+	for _, r := range sorted {
+		if last := len(merged) - 1; last >= 0 && r[0] <= merged[last][1]+1 {
+			if r[1] > merged[last][1] {
+				merged[last][1] = r[1]
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+
+	return RuneSet{ranges: merged}
+}
+
+// Function to get the runes in both sets
+func (s RuneSet) And(other RuneSet) RuneSet {
+	ranges := [][2]rune{}
+	for i, j := 0, 0; i < len(s.ranges) && j < len(other.ranges); {
+		a, b := s.ranges[i], other.ranges[j]
+		low, high := a[0], a[1]
+		if b[0] > low {
+			low = b[0]
+		}
+		if b[1] < high {
+			high = b[1]
+		}
+		if low <= high {
+			ranges = append(ranges, [2]rune{low, high})
+		}
+
+		if a[1] < b[1] {
+			i++
+		} else {
+			j++
+		}
+	}
+
+	return RuneSet{ranges: ranges}
+}
+
+// Function to get the runes in either set
+func (s RuneSet) Or(other RuneSet) RuneSet {
+	return newRuneSet(append(append([][2]rune{}, s.ranges...), other.ranges...))
+}
+
+// Function to get the runes up to unicode.MaxRune not in the set
+func (s RuneSet) Not() RuneSet {
+	ranges := [][2]rune{}
+	next := rune(0)
+	for _, r := range s.ranges {
+		if r[0] > next {
+			ranges = append(ranges, [2]rune{next, r[0] - 1})
+		}
+		next = r[1] + 1
+	}
+
+	if next <= unicode.MaxRune {
+		ranges = append(ranges, [2]rune{next, unicode.MaxRune})
+	}
+
+	return RuneSet{ranges: ranges}
+}
+
+// Function to get the runes in the set but not in the other one
+func (s RuneSet) Minus(other RuneSet) RuneSet {
+	return s.And(other.Not())
+}
+
+func (s RuneSet) IsEmpty() bool {
+	return len(s.ranges) == 0
+}
+
+// Function to check if the rune is in the set, by binary search over the ranges
+func (s RuneSet) Contains(char rune) bool {
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i][1] >= char })
+
+	return i < len(s.ranges) && s.ranges[i][0] <= char
+}
+
+func (s RuneSet) Equal(other RuneSet) bool {
+	if len(s.ranges) != len(other.ranges) {
+		return false
+	}
+
+	for i := range s.ranges {
+		if s.ranges[i] != other.ranges[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Function to get a copy of the sorted inclusive ranges of the set
+func (s RuneSet) Ranges() [][2]rune {
+	return append([][2]rune{}, s.ranges...)
+}
+
+// Function to get the set as a character class, like [0-9a-f]
+func (s RuneSet) String() string {
+	var class strings.Builder
+	class.WriteString("[")
+	for _, r := range s.ranges {
+		class.WriteString(escapeRegexRune(r[0], true))
+		if r[1] > r[0] {
+			class.WriteString("-" + escapeRegexRune(r[1], true))
+		}
+	}
+	class.WriteString("]")
+
+	return class.String()
+}
+
+// minterms splits the union of the sets into the coarsest partition whose blocks
+// are each inside or outside every set, sorted by lowest rune
+func minterms(sets []RuneSet) []RuneSet {
+	union := RuneSet{}
+	for _, set := range sets {
+		union = union.Or(set)
+	}
+	if union.IsEmpty() {
+		return nil
+	}
+
+	blocks := []RuneSet{union}
+	for _, set := range sets {
+		refined := []RuneSet{}
+		for _, block := range blocks {
+			for _, part := range []RuneSet{block.And(set), block.Minus(set)} {
+				if !part.IsEmpty() {
+					refined = append(refined, part)
+				}
+			}
+		}
+		blocks = refined
+	}
+
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i].ranges[0][0] < blocks[j].ranges[0][0] })
+
+	return blocks
+}
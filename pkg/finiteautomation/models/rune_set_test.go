@@ -0,0 +1,75 @@
+package models
+
+import (
+	"testing"
+	"unicode"
+)
+
+// TestRuneSet_Algebra tests intersection, union, complement and difference.
+func TestRuneSet_Algebra(t *testing.T) {
+	lower := RuneRange('a', 'z')
+	vowels := RuneOf('a', 'e', 'i', 'o', 'u')
+
+	if got := lower.And(RuneRange('x', '~')).String(); got != "[x-z]" {
+		t.Errorf("Expected [x-z], got %s", got)
+	}
+	if got := RuneRange('a', 'c').Or(RuneRange('d', 'f')).String(); got != "[a-f]" {
+		t.Errorf("Expected [a-f], got %s", got)
+	}
+	if !lower.Not().Not().Equal(lower) {
+		t.Errorf("Expected double complement to be the set")
+	}
+	if !lower.Or(lower.Not()).Equal(AnyRune()) {
+		t.Errorf("Expected set or complement to be every rune")
+	}
+	if !lower.And(lower.Not()).IsEmpty() {
+		t.Errorf("Expected set and complement to be empty")
+	}
+
+	consonants := lower.Minus(vowels)
+	for char, expected := range map[rune]bool{'b': true, 'z': true, 'a': false, 'u': false, 'B': false} {
+		if consonants.Contains(char) != expected {
+			t.Errorf("Expected %t for rune %c", expected, char)
+		}
+	}
+}
+
+// TestRuneCategory tests sets built from Unicode tables and predicates.
+func TestRuneCategory(t *testing.T) {
+	upper, err := RuneCategory("Lu")
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	if !upper.Equal(RunePredicate(unicode.IsUpper)) {
+		t.Errorf("Expected category Lu to equal the unicode.IsUpper predicate")
+	}
+
+	greek, err := RuneCategory("Greek")
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	if !greek.Contains('λ') || greek.Contains('l') {
+		t.Errorf("Expected script Greek to contain λ and not l")
+	}
+
+	if _, err := RuneCategory("Klingon"); err == nil {
+		t.Errorf("Expected error for unknown category, got nil")
+	}
+}
+
+// TestMinterms tests that minterms partition the union of the sets.
+func TestMinterms(t *testing.T) {
+	blocks := minterms([]RuneSet{RuneRange('a', 'm'), RuneRange('h', 'z')})
+
+	expected := []string{"[a-g]", "[h-m]", "[n-z]"}
+	if len(blocks) != len(expected) {
+		t.Fatalf("Expected %d minterms, got %d", len(expected), len(blocks))
+	}
+	for i, block := range blocks {
+		if block.String() != expected[i] {
+			t.Errorf("Expected minterm %s, got %s", expected[i], block)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package models
+
+import (
+	"encoding/binary"
+	"unicode/utf8"
+)
+
+// MatchKind selects which match is reported from a given start position
+type MatchKind int
+
+const (
+	// MatchLeftmostLongest reports the longest accepted substring from the
+	// leftmost start position
+	MatchLeftmostLongest MatchKind = iota
+	// MatchLeftmostFirst reports the substring ending at the first accepting state
+	// reached from the leftmost start position
+	MatchLeftmostFirst
+)
+
+// Match represents an accepted substring of a text.
+// It contains:
+//   - Start: the byte offset where the substring starts.
+//   - End: the byte offset right after the substring.
+//   - State: the accepting state the substring ends in.
+type Match struct {
+	Start int
+	End   int
+	State *State
+}
+
+// Function to find the first accepted substring of the text
+//   - start positions are tried from left to right, the first one with a match wins
+//   - empty substrings are not reported
+//   - runs in time linear in the text, see FindAll
+//   - returns false if nothing matches, the automaton is not initialized or it
+//     uses guarded transitions
+func (fa *FiniteAutomation) FindFirst(text string, kind MatchKind) (Match, bool) {
+	if fa.checkUnguarded() != nil {
+		return Match{}, false
+	}
+
+	match, _, ok := fa.newTextSearch(text).find(0, kind)
+
+	return match, ok
+}
+
+// Function to find all non-overlapping accepted substrings of the text
+//   - the search resumes at the end of every match
+//   - one backward pass over the text records, at every rune boundary, the states
+//     from which an accepting state can still be reached; the forward pass then
+//     only starts where a match starts and stops as soon as no longer match is
+//     possible, so the whole search is linear in the text
+//   - same rules as FindFirst, returns nil if nothing matches
+func (fa *FiniteAutomation) FindAll(text string, kind MatchKind) []Match {
+	if fa.checkUnguarded() != nil {
+		return nil
+	}
+
+	search := fa.newTextSearch(text)
+
+	var matches []Match
+	for start := 0; ; {
+		match, end, ok := search.find(start, kind)
+		if !ok {
+			return matches
+		}
+
+		matches = append(matches, match)
+		start = end
+	}
+}
+
+// textSearch holds a text split into input symbols along with the live set at
+// every rune boundary.
+// It contains:
+//   - offsets: the byte offset of every rune boundary, the last one is len(text).
+//   - symbols: the input symbol of every rune.
+//   - live: for every rune boundary, the id of the set of states from which an
+//     accepting state can be reached by reading the text after it.
+type textSearch struct {
+	fa      *FiniteAutomation
+	sets    *liveSets
+	offsets []int
+	symbols []string
+	live    []int
+}
+
+// newTextSearch splits the text into runes and runs the backward pass
+func (fa *FiniteAutomation) newTextSearch(text string) *textSearch {
+	search := &textSearch{fa: fa, sets: newLiveSets(fa.core)}
+	for offset := 0; offset < len(text); {
+		char, size := utf8.DecodeRuneInString(text[offset:])
+		search.offsets = append(search.offsets, offset)
+		search.symbols = append(search.symbols, string(char))
+		offset += size
+	}
+	search.offsets = append(search.offsets, len(text))
+
+	search.live = make([]int, len(search.offsets))
+	search.live[len(search.symbols)] = search.sets.accepting
+	for i := len(search.symbols) - 1; i >= 0; i-- {
+		search.live[i] = search.sets.step(search.live[i+1], search.symbols[i])
+	}
+
+	return search
+}
+
+// find returns the first non-empty match starting at or after the rune boundary
+// from, selected by kind, and the rune boundary it ends at
+func (search *textSearch) find(from int, kind MatchKind) (Match, int, bool) {
+	core := search.fa.core
+	for start := from; start < len(search.symbols); start++ {
+		state, ok := core.transitions[core.initialState][search.symbols[start]]
+		if !ok || !search.sets.contains(search.live[start+1], state) {
+			continue
+		}
+
+		// A match starts here, follow it while a longer one is still possible.
+		end, matchEnd, matchState := start+1, 0, 0
+		for {
+			if core.accepting[state] {
+				matchEnd, matchState = end, state
+				if kind == MatchLeftmostFirst {
+					break
+				}
+			}
+
+			if end == len(search.symbols) {
+				break
+			}
+
+			next, ok := core.transitions[state][search.symbols[end]]
+			if !ok || !search.sets.contains(search.live[end+1], next) {
+				break
+			}
+			state = next
+			end++
+		}
+
+		return Match{Start: search.offsets[start], End: search.offsets[matchEnd], State: search.fa.refs[matchState]}, matchEnd, true
+	}
+
+	return Match{}, 0, false
+}
+
+// liveSets is the reverse of a generic automaton, determinized lazily: its
+// states are sets of states, stored as bitsets, and reading a symbol backward
+// from a set gives the accepting states plus the states with a transition on
+// the symbol into the set
+//   - the sets met on a text are at most one per rune boundary, so the
+//     construction never explodes
+type liveSets struct {
+	core      *Automaton[string, string]
+	reverse   []map[string][]int
+	sets      [][]uint64
+	ids       map[string]int
+	next      []map[string]int
+	accepting int
+}
+
+// newLiveSets reverses the transitions of the automaton
+func newLiveSets(core *Automaton[string, string]) *liveSets {
+	l := &liveSets{core: core, reverse: make([]map[string][]int, len(core.outputs)), ids: map[string]int{}}
+	for from, transitions := range core.transitions {
+		for input, to := range transitions {
+			if l.reverse[to] == nil {
+				l.reverse[to] = map[string][]int{}
+			}
+			l.reverse[to][input] = append(l.reverse[to][input], from)
+		}
+	}
+
+	accepting := make([]uint64, (len(core.outputs)+63)/64)
+	for state, isAccepting := range core.accepting {
+		if isAccepting {
+			accepting[state/64] |= 1 << (uint(state) % 64)
+		}
+	}
+	l.accepting = l.add(accepting)
+
+	return l
+}
+
+// step returns the id of the set live before the symbol from the id of the set
+// live after it
+func (l *liveSets) step(id int, symbol string) int {
+	if next, ok := l.next[id][symbol]; ok {
+		return next
+	}
+
+	set := append([]uint64{}, l.sets[l.accepting]...)
+	for word, bits := range l.sets[id] {
+		for bit := 0; bits != 0; bit, bits = bit+1, bits>>1 {
+			if bits&1 == 0 {
+				continue
+			}
+
+			for _, from := range l.reverse[word*64+bit][symbol] {
+				set[from/64] |= 1 << (uint(from) % 64)
+			}
+		}
+	}
+
+	next := l.add(set)
+	if l.next[id] == nil {
+		l.next[id] = map[string]int{}
+	}
+	l.next[id][symbol] = next
+
+	return next
+}
+
+// add returns the id of the set, adding it if it is new
+func (l *liveSets) add(set []uint64) int {
+	key := make([]byte, 0, 8*len(set))
+	for _, word := range set {
+		key = binary.LittleEndian.AppendUint64(key, word)
+	}
+
+	if id, ok := l.ids[string(key)]; ok {
+		return id
+	}
+
+	l.ids[string(key)] = len(l.sets)
+	l.sets = append(l.sets, set)
+	l.next = append(l.next, nil)
+
+	return len(l.sets) - 1
+}
+
+// contains checks if the state is in the set of the id
+func (l *liveSets) contains(id int, state int) bool {
+	return l.sets[id][state/64]&(1<<(uint(state)%64)) != 0
+}
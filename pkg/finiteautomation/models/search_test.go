@@ -0,0 +1,210 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// GetMockOnesFiniteAutomation returns an automaton over {0, 1, x} accepting
+// the strings of one or more 1s.
+func GetMockOnesFiniteAutomation() FiniteAutomation {
+	start, ones := State{}, State{}
+	start.Initialize("start", map[string]*State{})
+	ones.Initialize("ones", map[string]*State{})
+
+	tf1, tf2 := TransitionFunction{}, TransitionFunction{}
+	tf1.Initialize(&start, "1", &ones)
+	tf2.Initialize(&ones, "1", &ones)
+
+	finiteStates := map[*State]*State{&start: &start, &ones: &ones}
+	inputs := map[string]bool{"0": true, "1": true, "x": true}
+
+	fa := FiniteAutomation{}
+	fa.InitializeFiniteAutomation(finiteStates, inputs, &start, []*State{&ones}, []TransitionFunction{tf1, tf2})
+
+	return fa
+}
+
+// bounds returns the start and end offsets of the matches.
+func bounds(matches []Match) [][2]int {
+	result := [][2]int{}
+	for _, match := range matches {
+		result = append(result, [2]int{match.Start, match.End})
+	}
+
+	return result
+}
+
+// TestFindAll_LeftmostLongest tests that FindAll reports the longest runs.
+func TestFindAll_LeftmostLongest(t *testing.T) {
+	fa := GetMockOnesFiniteAutomation()
+
+	matches := fa.FindAll("0110é111x1", MatchLeftmostLongest)
+
+	expected := [][2]int{{1, 3}, {6, 9}, {10, 11}}
+	if !reflect.DeepEqual(bounds(matches), expected) {
+		t.Errorf("Expected %v, got %v", expected, bounds(matches))
+	}
+
+	if matches[0].State.GetOutput() != "ones" {
+		t.Errorf("Expected %s, got %s", "ones", matches[0].State.GetOutput())
+	}
+}
+
+// TestFindAll_LeftmostFirst tests that FindAll stops at the first accepting state.
+func TestFindAll_LeftmostFirst(t *testing.T) {
+	fa := GetMockOnesFiniteAutomation()
+
+	matches := fa.FindAll("0110", MatchLeftmostFirst)
+
+	expected := [][2]int{{1, 2}, {2, 3}}
+	if !reflect.DeepEqual(bounds(matches), expected) {
+		t.Errorf("Expected %v, got %v", expected, bounds(matches))
+	}
+}
+
+// TestFindFirst_NoMatch tests that FindFirst reports no match.
+func TestFindFirst_NoMatch(t *testing.T) {
+	fa := GetMockOnesFiniteAutomation()
+
+	_, ok := fa.FindFirst("000x", MatchLeftmostLongest)
+
+	if ok {
+		t.Errorf("Expected no match")
+	}
+}
+
+// TestFindFirst_NoError tests that FindFirst reports the leftmost match.
+func TestFindFirst_NoError(t *testing.T) {
+	fa := GetMockOnesFiniteAutomation()
+
+	match, ok := fa.FindFirst("00111011", MatchLeftmostLongest)
+
+	if !ok || match.Start != 2 || match.End != 5 {
+		t.Errorf("Expected match [2, 5), got %v %t", match, ok)
+	}
+}
+
+// TestFindAll_InvalidUTF8 tests that a match over an invalid byte ends after the
+// byte read, not after the width of the replacement rune.
+func TestFindAll_InvalidUTF8(t *testing.T) {
+	start, replaced := newState("start"), newState("replaced")
+	tf := TransitionFunction{}
+	tf.Initialize(start, "�", replaced)
+	inputs := map[string]bool{"a": true, "b": true, "�": true}
+	fa, _ := newFiniteAutomation([]*State{start, replaced}, inputs, start, []*State{replaced}, []TransitionFunction{tf})
+
+	text := "a\xffb"
+	matches := fa.FindAll(text, MatchLeftmostLongest)
+
+	expected := [][2]int{{1, 2}}
+	if !reflect.DeepEqual(bounds(matches), expected) {
+		t.Errorf("Expected %v, got %v", expected, bounds(matches))
+	}
+}
+
+// GetMockSearchFiniteAutomations returns automata over {a, b, c, x} for a*b and
+// for a|a*c, whose scans from a start can run far past the match.
+func GetMockSearchFiniteAutomations() []*FiniteAutomation {
+	inputs := map[string]bool{"a": true, "b": true, "c": true, "x": true}
+	transition := func(from *State, input string, to *State) TransitionFunction {
+		tf := TransitionFunction{}
+		tf.Initialize(from, input, to)
+		return tf
+	}
+
+	start, end := newState("start"), newState("end")
+	aStarB, _ := newFiniteAutomation([]*State{start, end}, inputs, start, []*State{end}, []TransitionFunction{
+		transition(start, "a", start),
+		transition(start, "b", end),
+	})
+
+	q0, q1, q2, qc := newState("q0"), newState("a"), newState("aa"), newState("c")
+	aOrAStarC, _ := newFiniteAutomation([]*State{q0, q1, q2, qc}, inputs, q0, []*State{q1, qc}, []TransitionFunction{
+		transition(q0, "a", q1),
+		transition(q0, "c", qc),
+		transition(q1, "a", q2),
+		transition(q1, "c", qc),
+		transition(q2, "a", q2),
+		transition(q2, "c", qc),
+	})
+
+	return []*FiniteAutomation{aStarB, aOrAStarC}
+}
+
+// anchoredFindAll finds the matches by scanning the automaton from every start.
+func anchoredFindAll(fa *FiniteAutomation, text string, kind MatchKind) [][2]int {
+	result := [][2]int{}
+	for start := 0; start < len(text); {
+		end, ref := -1, fa.initialState
+		for i := start; i < len(text); i++ {
+			next, ok := ref.transition[text[i:i+1]]
+			if !ok {
+				break
+			}
+
+			ref = next
+			if fa.acceptingStates[ref] {
+				end = i + 1
+				if kind == MatchLeftmostFirst {
+					break
+				}
+			}
+		}
+
+		if end < 0 {
+			start++
+			continue
+		}
+
+		result = append(result, [2]int{start, end})
+		start = end
+	}
+
+	return result
+}
+
+// TestFindAll_MatchesAnchoredScan tests that FindAll reports the matches of a scan
+// from every start.
+func TestFindAll_MatchesAnchoredScan(t *testing.T) {
+	texts := []string{""}
+	for length := 1; length <= 6; length++ {
+		for _, text := range texts {
+			if len(text) == length-1 {
+				for _, char := range "abcx" {
+					texts = append(texts, text+string(char))
+				}
+			}
+		}
+	}
+
+	for _, fa := range GetMockSearchFiniteAutomations() {
+		for _, kind := range []MatchKind{MatchLeftmostLongest, MatchLeftmostFirst} {
+			for _, text := range texts {
+				expected := anchoredFindAll(fa, text, kind)
+				if got := bounds(fa.FindAll(text, kind)); !reflect.DeepEqual(got, expected) {
+					t.Errorf("Expected %v for %s, got %v", expected, text, got)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkFindAll_NoMatch(b *testing.B) {
+	fa := GetMockSearchFiniteAutomations()[0]
+	text := strings.Repeat("a", 20000)
+
+	for i := 0; i < b.N; i++ {
+		fa.FindAll(text, MatchLeftmostLongest)
+	}
+}
+
+func BenchmarkFindAll_ShortMatches(b *testing.B) {
+	fa := GetMockSearchFiniteAutomations()[1]
+	text := strings.Repeat("a", 20000)
+
+	for i := 0; i < b.N; i++ {
+		fa.FindAll(text, MatchLeftmostLongest)
+	}
+}
@@ -5,9 +5,15 @@ package models
 // - output: the associated output value of this state.
 // - transition: a mapping of input symbols (keys) to the next state,
 //   	allowing traversal through the automaton based on input.
+// - transitionOutput: a mapping of input symbols to the output emitted
+//   	when leaving this state through that input (Mealy output).
+// - guardedTransitions: a mapping of input symbols to the guarded transitions
+//   	leaving this state, kept in declaration order.
 type State struct {
-	output     string
-	transition map[string]*State
+	output             string
+	transition         map[string]*State
+	transitionOutput   map[string]string
+	guardedTransitions map[string][]TransitionFunction
 }
 
 func (st *State) Initialize(output string, transition map[string]*State) {
@@ -22,6 +28,36 @@ func (st *State) GetOutput() string {
 // addTransition adds or updates a transition for the given input symbol.
 // 	- If a transition for the input already exists, it will be overwritten
 // 	with the new target state.
-func (st *State) addTransition(input string, state *State) {
+// 	- An empty output removes any output previously attached to the input.
+func (st *State) addTransition(input string, state *State, output string) {
 	st.transition[input] = state
+
+	if output == "" {
+		delete(st.transitionOutput, input)
+		return
+	}
+
+	if st.transitionOutput == nil {
+		st.transitionOutput = map[string]string{}
+	}
+
+	st.transitionOutput[input] = output
+}
+
+// addGuardedTransition appends a guarded transition for its input symbol.
+// 	- Guarded transitions are evaluated in the order they were added.
+func (st *State) addGuardedTransition(transitionFunction TransitionFunction) {
+	if st.guardedTransitions == nil {
+		st.guardedTransitions = map[string][]TransitionFunction{}
+	}
+
+	st.guardedTransitions[transitionFunction.input] = append(st.guardedTransitions[transitionFunction.input], transitionFunction)
+}
+
+// newState returns an initialized state with the given output and no transitions.
+func newState(output string) *State {
+	st := &State{}
+	st.Initialize(output, map[string]*State{})
+
+	return st
 }
@@ -0,0 +1,166 @@
+package models
+
+import (
+	"sort"
+	"strconv"
+)
+
+// SuffixAutomaton is the minimal DFA accepting every substring of a text,
+// exposed as a FiniteAutomation.
+// It contains:
+//   - FiniteAutomation: the automaton, every state is accepting and outputs the
+//     number of occurrences of the substrings ending there.
+//   - ids: the id of every state in the arrays below.
+//   - next, link, length: the transitions, suffix links and longest substring
+//     length of every state, by id.
+//   - occurrences: the number of occurrences in the text of the substrings of
+//     every state, by id.
+type SuffixAutomaton struct {
+	*FiniteAutomation
+	ids         map[*State]int
+	next        []map[rune]int
+	link        []int
+	length      []int
+	occurrences []int
+}
+
+// Function to build the suffix automaton of the text in linear time
+//   - the input symbols are the runes of the text
+func NewSuffixAutomaton(text string) (*SuffixAutomaton, error) {
+	s := &SuffixAutomaton{
+		next:   []map[rune]int{{}},
+		link:   []int{-1},
+		length: []int{0},
+	}
+	cloned := []bool{false}
+
+	last := 0
+	for _, char := range text {
+		current := len(s.next)
+		s.next = append(s.next, map[rune]int{})
+		s.link = append(s.link, 0)
+		s.length = append(s.length, s.length[last]+1)
+		cloned = append(cloned, false)
+
+		p := last
+		for p != -1 {
+			if _, ok := s.next[p][char]; ok {
+				break
+			}
+			s.next[p][char] = current
+			p = s.link[p]
+		}
+
+		if p != -1 {
+			q := s.next[p][char]
+			if s.length[p]+1 == s.length[q] {
+				s.link[current] = q
+			} else {
+				clone := len(s.next)
+				s.next = append(s.next, map[rune]int{})
+				for c, target := range s.next[q] {
+					s.next[clone][c] = target
+				}
+				s.link = append(s.link, s.link[q])
+				s.length = append(s.length, s.length[p]+1)
+				cloned = append(cloned, true)
+
+				for p != -1 && s.next[p][char] == q {
+					s.next[p][char] = clone
+					p = s.link[p]
+				}
+				s.link[q] = clone
+				s.link[current] = clone
+			}
+		}
+
+		last = current
+	}
+
+	// Every non-cloned state ends one prefix of the text, the occurrences of a
+	// state are the prefixes ending in its subtree of suffix links.
+	s.occurrences = make([]int, len(s.next))
+	order := make([]int, len(s.next))
+	for id := range s.next {
+		order[id] = id
+		if id > 0 && !cloned[id] {
+			s.occurrences[id] = 1
+		}
+	}
+	sort.Slice(order, func(i, j int) bool { return s.length[order[i]] > s.length[order[j]] })
+	for _, id := range order {
+		if s.link[id] > 0 {
+			s.occurrences[s.link[id]] += s.occurrences[id]
+		}
+	}
+	s.occurrences[0] = len([]rune(text)) + 1
+
+	states := make([]*State, len(s.next))
+	s.ids = map[*State]int{}
+	inputs := map[string]bool{}
+	for id := range s.next {
+		states[id] = newState(strconv.Itoa(s.occurrences[id]))
+		s.ids[states[id]] = id
+	}
+
+	transitionFunctions := []TransitionFunction{}
+	for id, transitions := range s.next {
+		for char, target := range transitions {
+			inputs[string(char)] = true
+			tf := TransitionFunction{}
+			tf.Initialize(states[id], string(char), states[target])
+			transitionFunctions = append(transitionFunctions, tf)
+		}
+	}
+
+	fa, err := newFiniteAutomation(states, inputs, states[0], states, transitionFunctions)
+	if err != nil {
+		return nil, err
+	}
+	s.FiniteAutomation = fa
+
+	return s, nil
+}
+
+// Function to count the occurrences of the pattern in the text, overlaps included
+//   - the empty pattern occurs once at every position, including the end
+func (s *SuffixAutomaton) Occurrences(pattern string) int {
+	id := 0
+	for _, char := range pattern {
+		next, ok := s.next[id][char]
+		if !ok {
+			return 0
+		}
+		id = next
+	}
+
+	return s.occurrences[id]
+}
+
+// Function to find the longest common substring of the text and another string
+//   - the first one found in the other string is returned when there are several
+func (s *SuffixAutomaton) LongestCommonSubstring(other string) string {
+	runes := []rune(other)
+	id, length := 0, 0
+	bestLength, bestEnd := 0, 0
+	for i, char := range runes {
+		for id != 0 {
+			if _, ok := s.next[id][char]; ok {
+				break
+			}
+			id = s.link[id]
+			length = s.length[id]
+		}
+
+		if next, ok := s.next[id][char]; ok {
+			id = next
+			length++
+		}
+
+		if length > bestLength {
+			bestLength, bestEnd = length, i+1
+		}
+	}
+
+	return string(runes[bestEnd-bestLength : bestEnd])
+}
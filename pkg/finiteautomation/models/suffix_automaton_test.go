@@ -0,0 +1,57 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestSuffixAutomaton_AcceptsSubstrings tests that exactly the substrings of the
+// text are accepted.
+func TestSuffixAutomaton_AcceptsSubstrings(t *testing.T) {
+	text := "0110100"
+	s, err := NewSuffixAutomaton(text)
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	for _, input := range binaryStrings(8) {
+		_, err := s.Compute(input)
+
+		if (err == nil) != strings.Contains(text, input) {
+			t.Errorf("Expected %t for input %s", strings.Contains(text, input), input)
+		}
+	}
+
+	minimized, _ := s.MinimizeTransducer()
+	equivalent, _ := AreTransducersEquivalent(s.FiniteAutomation, minimized)
+	if !equivalent {
+		t.Errorf("Expected the suffix automaton to survive minimization")
+	}
+}
+
+// TestSuffixAutomaton_Occurrences tests occurrence counts, overlaps included.
+func TestSuffixAutomaton_Occurrences(t *testing.T) {
+	s, _ := NewSuffixAutomaton("abababa")
+
+	for pattern, expected := range map[string]int{"aba": 3, "b": 3, "abababa": 1, "c": 0, "bb": 0, "": 8} {
+		if s.Occurrences(pattern) != expected {
+			t.Errorf("Expected %d occurrences of %s, got %d", expected, pattern, s.Occurrences(pattern))
+		}
+	}
+
+	result, err := s.Compute("ab")
+	if err != nil || *result != "3" {
+		t.Errorf("Expected output %s for ab, got %v %v", "3", result, err)
+	}
+}
+
+// TestSuffixAutomaton_LongestCommonSubstring tests the longest common substring query.
+func TestSuffixAutomaton_LongestCommonSubstring(t *testing.T) {
+	s, _ := NewSuffixAutomaton("the quick brown fox")
+
+	for other, expected := range map[string]string{"a quick brew": " quick br", "zzz": "", "fox": "fox"} {
+		if result := s.LongestCommonSubstring(other); result != expected {
+			t.Errorf("Expected %q, got %q for %s", expected, result, other)
+		}
+	}
+}
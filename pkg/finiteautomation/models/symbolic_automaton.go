@@ -0,0 +1,515 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// SymbolicAutomaton defines a nondeterministic automaton whose transitions are
+// labeled with sets of runes instead of single input symbols.
+// It includes:
+//   - outputs: the output of every state, indexed by state id.
+//   - accepting: for every state id, whether the state is an accepting state.
+//   - transitions: for every state id, the labeled transitions leaving it, at most
+//     one per target.
+//   - initialState: the id of the starting state.
+type SymbolicAutomaton struct {
+	outputs      []string
+	accepting    []bool
+	transitions  [][]symbolicTransition
+	initialState int
+}
+
+// symbolicTransition is a transition taken on any rune of its label
+type symbolicTransition struct {
+	label  RuneSet
+	target int
+}
+
+// Function to create a symbolic automaton
+//   - the automaton has no states yet, the first state added becomes the initial state
+func NewSymbolicAutomaton() *SymbolicAutomaton {
+	return &SymbolicAutomaton{}
+}
+
+// Function to add a state - returns the id of the new state
+func (sa *SymbolicAutomaton) AddState(output string, accepting bool) int {
+	sa.outputs = append(sa.outputs, output)
+	sa.accepting = append(sa.accepting, accepting)
+	sa.transitions = append(sa.transitions, nil)
+
+	return len(sa.outputs) - 1
+}
+
+// Function to add a transition from one state to another on any rune of the label
+//   - both states must exist, empty labels are ignored
+//   - labels of transitions between the same states are merged, labels leaving a
+//     state may overlap
+func (sa *SymbolicAutomaton) AddTransition(from int, label RuneSet, to int) error {
+	if !sa.isState(from) || !sa.isState(to) {
+		return errors.New(fmt.Sprintln("Transition Function invalid - State not in the set of states: ", from, to))
+	}
+
+	if label.IsEmpty() {
+		return nil
+	}
+
+	for i, transition := range sa.transitions[from] {
+		if transition.target == to {
+			sa.transitions[from][i].label = transition.label.Or(label)
+			return nil
+		}
+	}
+
+	sa.transitions[from] = append(sa.transitions[from], symbolicTransition{label: label, target: to})
+
+	return nil
+}
+
+// Function to set the initial state
+func (sa *SymbolicAutomaton) SetInitialState(state int) error {
+	if !sa.isState(state) {
+		return errors.New(fmt.Sprintln("Initial State invalid - Initial state not in the set of states: ", state))
+	}
+
+	sa.initialState = state
+
+	return nil
+}
+
+func (sa *SymbolicAutomaton) NumStates() int {
+	return len(sa.outputs)
+}
+
+func (sa *SymbolicAutomaton) GetInitialState() int {
+	return sa.initialState
+}
+
+func (sa *SymbolicAutomaton) GetOutput(state int) string {
+	return sa.outputs[state]
+}
+
+func (sa *SymbolicAutomaton) IsAccepting(state int) bool {
+	return sa.accepting[state]
+}
+
+// Function to get the label of the transition between two states - empty if
+// there is none
+func (sa *SymbolicAutomaton) GetLabel(from int, to int) RuneSet {
+	for _, transition := range sa.transitions[from] {
+		if transition.target == to {
+			return transition.label
+		}
+	}
+
+	return RuneSet{}
+}
+
+// Function to check if the input is accepted by simulating the set of active states
+func (sa *SymbolicAutomaton) Accepts(input string) bool {
+	if sa == nil || len(sa.outputs) == 0 {
+		return false
+	}
+
+	current := []int{sa.initialState}
+	for _, char := range input {
+		next := map[int]bool{}
+		for _, state := range current {
+			for _, transition := range sa.transitions[state] {
+				if transition.label.Contains(char) {
+					next[transition.target] = true
+				}
+			}
+		}
+
+		if len(next) == 0 {
+			return false
+		}
+
+		current = current[:0]
+		for state := range next {
+			current = append(current, state)
+		}
+	}
+
+	for _, state := range current {
+		if sa.accepting[state] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Function to check if the automaton accepts no word
+func (sa *SymbolicAutomaton) IsEmpty() bool {
+	if sa == nil || len(sa.outputs) == 0 {
+		return true
+	}
+
+	for _, state := range sa.reachable() {
+		if sa.accepting[state] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Function to convert the automaton into an equivalent deterministic one (subset
+// construction over minterms)
+//   - the labels leaving a set of states are split into minterms, the runes of a
+//     minterm all lead to the same set of states
+//   - outputs follow NFA.Determinize: a set outputs its lowest accepting state id,
+//     or its lowest state id if none is accepting
+//   - transitions to the empty set are left undefined
+func (sa *SymbolicAutomaton) Determinize() (*SymbolicAutomaton, error) {
+	if sa == nil || len(sa.outputs) == 0 {
+		return nil, errors.New("symbolic automaton has not been initialized")
+	}
+
+	d := NewSymbolicAutomaton()
+	subsets := map[string]int{}
+	pending := [][]int{}
+	visit := func(set []int) int {
+		key := setKey(set)
+		if id, ok := subsets[key]; ok {
+			return id
+		}
+
+		output, accepting := sa.outputs[set[0]], false
+		for _, member := range set {
+			if sa.accepting[member] {
+				output, accepting = sa.outputs[member], true
+				break
+			}
+		}
+
+		id := d.AddState(output, accepting)
+		subsets[key] = id
+		pending = append(pending, set)
+
+		return id
+	}
+
+	visit([]int{sa.initialState})
+	for i := 0; i < len(pending); i++ {
+		labels := []RuneSet{}
+		for _, state := range pending[i] {
+			for _, transition := range sa.transitions[state] {
+				labels = append(labels, transition.label)
+			}
+		}
+
+		for _, minterm := range minterms(labels) {
+			char := minterm.ranges[0][0]
+			seen := map[int]bool{}
+			next := []int{}
+			for _, state := range pending[i] {
+				for _, transition := range sa.transitions[state] {
+					if transition.label.Contains(char) && !seen[transition.target] {
+						seen[transition.target] = true
+						next = append(next, transition.target)
+					}
+				}
+			}
+			sort.Ints(next)
+
+			d.AddTransition(i, minterm, visit(next))
+		}
+	}
+
+	return d, nil
+}
+
+// Function to build the minimal deterministic automaton of the same language
+//   - states are refined over the minterms of all labels until equivalent states
+//     share a block, each block outputs its lowest state id of the determinized
+//     automaton
+//   - states that cannot reach an accepting state are removed, except the
+//     initial state, so transitions to them are left undefined
+//   - state ids follow the breadth-first order from the initial state
+func (sa *SymbolicAutomaton) Minimize() (*SymbolicAutomaton, error) {
+	d, err := sa.Determinize()
+	if err != nil {
+		return nil, err
+	}
+	d.complete()
+
+	labels := []RuneSet{}
+	for _, transitions := range d.transitions {
+		for _, transition := range transitions {
+			labels = append(labels, transition.label)
+		}
+	}
+	classes := minterms(labels)
+
+	// next[state][class] is the target of the state on the runes of the class.
+	next := make([][]int, d.NumStates())
+	for state := range next {
+		next[state] = make([]int, len(classes))
+		for class, minterm := range classes {
+			next[state][class] = d.step(state, minterm.ranges[0][0])
+		}
+	}
+
+	blocks := make([]int, d.NumStates())
+	for state := range blocks {
+		if d.accepting[state] {
+			blocks[state] = 1
+		}
+	}
+	for count := 0; ; {
+		signatures := map[string]int{}
+		refined := make([]int, len(blocks))
+		for state := range blocks {
+			signature := []int{blocks[state]}
+			for _, target := range next[state] {
+				signature = append(signature, blocks[target])
+			}
+
+			key := setKey(signature)
+			if _, ok := signatures[key]; !ok {
+				signatures[key] = len(signatures)
+			}
+			refined[state] = signatures[key]
+		}
+
+		blocks = refined
+		if len(signatures) == count {
+			break
+		}
+		count = len(signatures)
+	}
+
+	live := d.coreachable()
+	m := NewSymbolicAutomaton()
+	ids := map[int]int{}
+	representatives := map[int]int{}
+	for state := range blocks {
+		if _, ok := representatives[blocks[state]]; !ok {
+			representatives[blocks[state]] = state
+		}
+	}
+	queue := []int{blocks[d.initialState]}
+	ids[queue[0]] = m.AddState(d.outputs[representatives[queue[0]]], d.accepting[representatives[queue[0]]])
+	for ; len(queue) > 0; queue = queue[1:] {
+		state := representatives[queue[0]]
+		for class, target := range next[state] {
+			if !live[target] {
+				continue
+			}
+
+			block := blocks[target]
+			if _, ok := ids[block]; !ok {
+				ids[block] = m.AddState(d.outputs[representatives[block]], d.accepting[representatives[block]])
+				queue = append(queue, block)
+			}
+			m.AddTransition(ids[queue[0]], classes[class], ids[block])
+		}
+	}
+
+	return m, nil
+}
+
+// Function to build the automaton accepting the words accepted by both automata
+func (sa *SymbolicAutomaton) Intersect(other *SymbolicAutomaton) (*SymbolicAutomaton, error) {
+	return sa.product(other, func(a bool, b bool) bool { return a && b })
+}
+
+// Function to build the automaton accepting the words accepted by either automaton
+func (sa *SymbolicAutomaton) Union(other *SymbolicAutomaton) (*SymbolicAutomaton, error) {
+	return sa.product(other, func(a bool, b bool) bool { return a || b })
+}
+
+// Function to build the automaton accepting the words accepted by this automaton
+// but not by the other one
+func (sa *SymbolicAutomaton) Difference(other *SymbolicAutomaton) (*SymbolicAutomaton, error) {
+	return sa.product(other, func(a bool, b bool) bool { return a && !b })
+}
+
+// Function to build the automaton accepting every rune string this automaton
+// rejects
+//   - the automaton is determinized and completed with a non-accepting sink state
+//     before the accepting states are flipped
+func (sa *SymbolicAutomaton) Complement() (*SymbolicAutomaton, error) {
+	d, err := sa.Determinize()
+	if err != nil {
+		return nil, err
+	}
+
+	d.complete()
+	for state := range d.accepting {
+		d.accepting[state] = !d.accepting[state]
+	}
+
+	return d, nil
+}
+
+// product builds the product of the completed determinized automata, the pairs
+// of states are accepting when the function of their acceptance holds
+//   - each pair outputs the output of the state of this automaton
+func (sa *SymbolicAutomaton) product(other *SymbolicAutomaton, accept func(bool, bool) bool) (*SymbolicAutomaton, error) {
+	a, err := sa.Determinize()
+	if err != nil {
+		return nil, err
+	}
+	a.complete()
+
+	b, err := other.Determinize()
+	if err != nil {
+		return nil, err
+	}
+	b.complete()
+
+	p := NewSymbolicAutomaton()
+	ids := map[[2]int]int{}
+	visit := func(pair [2]int) (int, bool) {
+		if id, ok := ids[pair]; ok {
+			return id, false
+		}
+
+		ids[pair] = p.AddState(a.outputs[pair[0]], accept(a.accepting[pair[0]], b.accepting[pair[1]]))
+
+		return ids[pair], true
+	}
+
+	start := [2]int{a.initialState, b.initialState}
+	visit(start)
+	for queue := [][2]int{start}; len(queue) > 0; queue = queue[1:] {
+		pair := queue[0]
+		for _, ta := range a.transitions[pair[0]] {
+			for _, tb := range b.transitions[pair[1]] {
+				label := ta.label.And(tb.label)
+				if label.IsEmpty() {
+					continue
+				}
+
+				target := [2]int{ta.target, tb.target}
+				id, added := visit(target)
+				if added {
+					queue = append(queue, target)
+				}
+				p.AddTransition(ids[pair], label, id)
+			}
+		}
+	}
+
+	return p, nil
+}
+
+// Function to convert the FiniteAutomation into a symbolic automaton, every input
+// symbol becoming a single-rune label
+//   - input symbols must be single runes and guarded transitions are not supported
+func (fa *FiniteAutomation) ToSymbolic() (*SymbolicAutomaton, error) {
+	n, err := fa.ToNFA()
+	if err != nil {
+		return nil, err
+	}
+
+	for input := range n.inputs {
+		if utf8.RuneCountInString(input) != 1 {
+			return nil, errors.New(fmt.Sprintln("Invalid alphabet - symbol", input, "is not a single rune"))
+		}
+	}
+
+	sa := NewSymbolicAutomaton()
+	for state := range n.outputs {
+		sa.AddState(n.outputs[state], n.accepting[state])
+	}
+
+	for state, transitions := range n.transitions {
+		for _, input := range sortedSymbols(n.inputs) {
+			char, _ := utf8.DecodeRuneInString(input)
+			for _, target := range transitions[input] {
+				sa.AddTransition(state, RuneOf(char), target)
+			}
+		}
+	}
+	sa.initialState = n.initialState
+
+	return sa, nil
+}
+
+// complete adds a non-accepting sink state taking the runes no transition of a
+// state takes, for deterministic automata
+func (sa *SymbolicAutomaton) complete() {
+	sink := -1
+	for state := range sa.transitions {
+		covered := RuneSet{}
+		for _, transition := range sa.transitions[state] {
+			covered = covered.Or(transition.label)
+		}
+
+		missing := covered.Not()
+		if missing.IsEmpty() {
+			continue
+		}
+
+		if sink == -1 {
+			sink = sa.AddState("", false)
+			sa.AddTransition(sink, AnyRune(), sink)
+		}
+		sa.AddTransition(state, missing, sink)
+	}
+}
+
+// step returns the target of a deterministic state on the rune, or -1
+func (sa *SymbolicAutomaton) step(state int, char rune) int {
+	for _, transition := range sa.transitions[state] {
+		if transition.label.Contains(char) {
+			return transition.target
+		}
+	}
+
+	return -1
+}
+
+// reachable returns the states reachable from the initial state
+func (sa *SymbolicAutomaton) reachable() []int {
+	seen := map[int]bool{sa.initialState: true}
+	queue := []int{sa.initialState}
+	for i := 0; i < len(queue); i++ {
+		for _, transition := range sa.transitions[queue[i]] {
+			if !seen[transition.target] {
+				seen[transition.target] = true
+				queue = append(queue, transition.target)
+			}
+		}
+	}
+
+	return queue
+}
+
+// coreachable returns the states from which an accepting state can be reached
+func (sa *SymbolicAutomaton) coreachable() map[int]bool {
+	predecessors := make([][]int, len(sa.outputs))
+	queue := []int{}
+	live := map[int]bool{}
+	for state, transitions := range sa.transitions {
+		for _, transition := range transitions {
+			predecessors[transition.target] = append(predecessors[transition.target], state)
+		}
+		if sa.accepting[state] {
+			live[state] = true
+			queue = append(queue, state)
+		}
+	}
+
+	for i := 0; i < len(queue); i++ {
+		for _, state := range predecessors[queue[i]] {
+			if !live[state] {
+				live[state] = true
+				queue = append(queue, state)
+			}
+		}
+	}
+
+	return live
+}
+
+func (sa *SymbolicAutomaton) isState(state int) bool {
+	return state >= 0 && state < len(sa.outputs)
+}
@@ -0,0 +1,198 @@
+package models
+
+import (
+	"testing"
+	"unicode"
+)
+
+// GetMockIdentifierSymbolicAutomaton returns an automaton accepting a Unicode
+// letter followed by letters and digits.
+func GetMockIdentifierSymbolicAutomaton() *SymbolicAutomaton {
+	letter, _ := RuneCategory("L")
+	digit := RunePredicate(unicode.IsDigit)
+
+	sa := NewSymbolicAutomaton()
+	start := sa.AddState("start", false)
+	identifier := sa.AddState("identifier", true)
+	sa.AddTransition(start, letter, identifier)
+	sa.AddTransition(identifier, letter.Or(digit), identifier)
+
+	return sa
+}
+
+// GetMockContainsSymbolicAutomaton returns a nondeterministic automaton accepting
+// the strings containing the word.
+func GetMockContainsSymbolicAutomaton(word string) *SymbolicAutomaton {
+	sa := NewSymbolicAutomaton()
+	state := sa.AddState("", false)
+	sa.AddTransition(state, AnyRune(), state)
+	for _, char := range word {
+		next := sa.AddState("", false)
+		sa.AddTransition(state, RuneOf(char), next)
+		state = next
+	}
+	sa.accepting[state] = true
+	sa.AddTransition(state, AnyRune(), state)
+
+	return sa
+}
+
+// assertSymbolicLanguage checks both automata agree on the inputs.
+func assertSymbolicLanguage(t *testing.T, sa *SymbolicAutomaton, expected func(string) bool, inputs []string) {
+	for _, input := range inputs {
+		if sa.Accepts(input) != expected(input) {
+			t.Errorf("Expected %t for input %q", expected(input), input)
+		}
+	}
+}
+
+var symbolicInputs = []string{"", "x", "héllo", "λx1", "Ωmega2", "1abc", "ab", "cab", "abab", "a b", "日本語", "x_y", "bab9"}
+
+// TestSymbolicAutomaton_Accepts tests matching with predicate labels.
+func TestSymbolicAutomaton_Accepts(t *testing.T) {
+	sa := GetMockIdentifierSymbolicAutomaton()
+
+	for input, expected := range map[string]bool{"héllo": true, "λx1": true, "日本語": true, "Ωmega2": true, "1abc": false, "x_y": false, "": false} {
+		if sa.Accepts(input) != expected {
+			t.Errorf("Expected %t for input %q", expected, input)
+		}
+	}
+}
+
+// TestSymbolicAutomaton_Determinize tests that determinization keeps the language
+// and leaves no overlapping labels.
+func TestSymbolicAutomaton_Determinize(t *testing.T) {
+	n := GetMockContainsSymbolicAutomaton("ab")
+	d, err := n.Determinize()
+
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	for state := 0; state < d.NumStates(); state++ {
+		covered := RuneSet{}
+		for _, transition := range d.transitions[state] {
+			if !covered.And(transition.label).IsEmpty() {
+				t.Errorf("Expected disjoint labels from state %d", state)
+			}
+			covered = covered.Or(transition.label)
+		}
+	}
+
+	assertSymbolicLanguage(t, d, n.Accepts, symbolicInputs)
+}
+
+// TestSymbolicAutomaton_Minimize tests that minimization merges equivalent states.
+func TestSymbolicAutomaton_Minimize(t *testing.T) {
+	n := GetMockContainsSymbolicAutomaton("ab")
+	m, err := n.Minimize()
+
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	if m.NumStates() != 3 {
+		t.Errorf("Expected 3 states, got %d", m.NumStates())
+	}
+
+	assertSymbolicLanguage(t, m, n.Accepts, symbolicInputs)
+}
+
+// TestSymbolicAutomaton_Minimize_RemovesDeadStates tests that states that cannot
+// accept are removed.
+func TestSymbolicAutomaton_Minimize_RemovesDeadStates(t *testing.T) {
+	sa := GetMockIdentifierSymbolicAutomaton()
+	dead := sa.AddState("dead", false)
+	sa.AddTransition(0, RunePredicate(unicode.IsDigit), dead)
+
+	m, err := sa.Minimize()
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	if m.NumStates() != 2 {
+		t.Errorf("Expected 2 states, got %d", m.NumStates())
+	}
+	if !m.GetLabel(0, 1).Equal(sa.GetLabel(0, 1)) {
+		t.Errorf("Expected label %s, got %s", sa.GetLabel(0, 1), m.GetLabel(0, 1))
+	}
+}
+
+// TestSymbolicAutomaton_BooleanOperations tests intersection, union, difference
+// and complement.
+func TestSymbolicAutomaton_BooleanOperations(t *testing.T) {
+	identifier := GetMockIdentifierSymbolicAutomaton()
+	contains := GetMockContainsSymbolicAutomaton("ab")
+
+	intersection, err := identifier.Intersect(contains)
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	union, _ := identifier.Union(contains)
+	difference, _ := identifier.Difference(contains)
+	complement, _ := identifier.Complement()
+
+	assertSymbolicLanguage(t, intersection, func(input string) bool {
+		return identifier.Accepts(input) && contains.Accepts(input)
+	}, symbolicInputs)
+	assertSymbolicLanguage(t, union, func(input string) bool {
+		return identifier.Accepts(input) || contains.Accepts(input)
+	}, symbolicInputs)
+	assertSymbolicLanguage(t, difference, func(input string) bool {
+		return identifier.Accepts(input) && !contains.Accepts(input)
+	}, symbolicInputs)
+	assertSymbolicLanguage(t, complement, func(input string) bool {
+		return !identifier.Accepts(input)
+	}, symbolicInputs)
+}
+
+// TestSymbolicAutomaton_IsEmpty tests emptiness, used to check equivalence.
+func TestSymbolicAutomaton_IsEmpty(t *testing.T) {
+	n := GetMockContainsSymbolicAutomaton("ab")
+	m, _ := n.Minimize()
+
+	left, _ := n.Difference(m)
+	right, _ := m.Difference(n)
+	if !left.IsEmpty() || !right.IsEmpty() {
+		t.Errorf("Expected minimized automaton to be equivalent")
+	}
+
+	identifier := GetMockIdentifierSymbolicAutomaton()
+	difference, _ := identifier.Difference(n)
+	if difference.IsEmpty() {
+		t.Errorf("Expected non-empty difference")
+	}
+}
+
+// TestToSymbolic_NoError tests the conversion from a FiniteAutomation.
+func TestToSymbolic_NoError(t *testing.T) {
+	fa := GetMockOnesFiniteAutomation()
+	sa, err := fa.ToSymbolic()
+
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	for _, input := range append(binaryStrings(6), "1x", "x") {
+		_, err := fa.Compute(input)
+
+		if sa.Accepts(input) != (err == nil) {
+			t.Errorf("Expected %t for input %s", err == nil, input)
+		}
+	}
+}
+
+// TestSymbolicAutomaton_ErrorUninitialized tests operations on an empty automaton.
+func TestSymbolicAutomaton_ErrorUninitialized(t *testing.T) {
+	sa := NewSymbolicAutomaton()
+
+	if _, err := sa.Determinize(); err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+	if _, err := sa.Complement(); err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+	if err := sa.AddTransition(0, AnyRune(), 1); err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+}
@@ -0,0 +1,314 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Function to compose two transducers - the output of t1 is fed as input into t2
+//   - every rune of a t1 transition output is read as one t2 input symbol
+//   - the composed transition outputs the concatenated t2 outputs
+//   - a composed state outputs the output of its t2 state and is accepting
+//     when both of its states are accepting
+//   - transitions whose t1 output cannot be read by t2 are left undefined
+func Compose(t1 *FiniteAutomation, t2 *FiniteAutomation) (*FiniteAutomation, error) {
+	for _, fa := range []*FiniteAutomation{t1, t2} {
+		if err := fa.checkUnguarded(); err != nil {
+			return nil, err
+		}
+	}
+
+	type pair struct {
+		first  *State
+		second *State
+	}
+
+	composed := map[pair]*State{}
+	pairs := []pair{}
+	states := []*State{}
+	acceptingStates := []*State{}
+	visit := func(p pair) *State {
+		if state, ok := composed[p]; ok {
+			return state
+		}
+
+		state := newState(p.second.output)
+		composed[p] = state
+		pairs = append(pairs, p)
+		states = append(states, state)
+		if t1.acceptingStates[p.first] && t2.acceptingStates[p.second] {
+			acceptingStates = append(acceptingStates, state)
+		}
+
+		return state
+	}
+
+	initialState := visit(pair{t1.initialState, t2.initialState})
+	inputs := t1.sortedInputs()
+	transitionFunctions := []TransitionFunction{}
+	for i := 0; i < len(pairs); i++ {
+		p := pairs[i]
+		for _, input := range inputs {
+			next, ok := p.first.transition[input]
+			if !ok {
+				continue
+			}
+
+			second, output, ok := t2.feed(p.second, p.first.transitionOutput[input])
+			if !ok {
+				continue
+			}
+
+			tf := TransitionFunction{}
+			tf.Initialize(states[i], input, visit(pair{next, second}))
+			tf.SetOutput(output)
+			transitionFunctions = append(transitionFunctions, tf)
+		}
+	}
+
+	return newFiniteAutomation(states, t1.inputs, initialState, acceptingStates, transitionFunctions)
+}
+
+// Function to minimize a deterministic Mealy or Moore machine
+//   - only states reachable from the initial state that can still reach an
+//     accepting state are kept, transitions into other states are dropped
+//   - two states are merged only if they agree on acceptance, state output and
+//     the output of every transition, and keep agreeing after any input
+func (fa *FiniteAutomation) MinimizeTransducer() (*FiniteAutomation, error) {
+	if err := fa.checkUnguarded(); err != nil {
+		return nil, err
+	}
+
+	inputs := fa.sortedInputs()
+	live := fa.liveStates()
+	states := []*State{fa.initialState}
+	kept := map[*State]bool{fa.initialState: true}
+	for i := 0; i < len(states); i++ {
+		for _, input := range inputs {
+			if next, ok := states[i].transition[input]; ok && live[next] && !kept[next] {
+				kept[next] = true
+				states = append(states, next)
+			}
+		}
+	}
+
+	// Start from the classes of states with the same observable behavior on a
+	// single step, then split classes until every member agrees on the class of
+	// every successor.
+	class := map[*State]int{}
+	count := 0
+	signatures := map[string]int{}
+	for _, state := range states {
+		var signature strings.Builder
+		fmt.Fprintf(&signature, "%t|%q", fa.acceptingStates[state], state.output)
+		for _, input := range inputs {
+			if next, ok := state.transition[input]; ok && live[next] {
+				fmt.Fprintf(&signature, "|%q", state.transitionOutput[input])
+			} else {
+				signature.WriteString("|-")
+			}
+		}
+
+		if _, ok := signatures[signature.String()]; !ok {
+			signatures[signature.String()] = count
+			count++
+		}
+		class[state] = signatures[signature.String()]
+	}
+
+	for {
+		next := map[*State]int{}
+		nextCount := 0
+		signatures := map[string]int{}
+		for _, state := range states {
+			var signature strings.Builder
+			fmt.Fprintf(&signature, "%d", class[state])
+			for _, input := range inputs {
+				if target, ok := state.transition[input]; ok && live[target] {
+					fmt.Fprintf(&signature, "|%d", class[target])
+				} else {
+					signature.WriteString("|-")
+				}
+			}
+
+			if _, ok := signatures[signature.String()]; !ok {
+				signatures[signature.String()] = nextCount
+				nextCount++
+			}
+			next[state] = signatures[signature.String()]
+		}
+
+		class = next
+		if nextCount == count {
+			break
+		}
+		count = nextCount
+	}
+
+	minimized := make([]*State, count)
+	acceptingStates := []*State{}
+	for _, state := range states {
+		if minimized[class[state]] == nil {
+			minimized[class[state]] = newState(state.output)
+			if fa.acceptingStates[state] {
+				acceptingStates = append(acceptingStates, minimized[class[state]])
+			}
+		}
+	}
+
+	transitionFunctions := []TransitionFunction{}
+	done := make([]bool, count)
+	for _, state := range states {
+		if done[class[state]] {
+			continue
+		}
+		done[class[state]] = true
+
+		for _, input := range inputs {
+			target, ok := state.transition[input]
+			if !ok || !live[target] {
+				continue
+			}
+
+			tf := TransitionFunction{}
+			tf.Initialize(minimized[class[state]], input, minimized[class[target]])
+			tf.SetOutput(state.transitionOutput[input])
+			transitionFunctions = append(transitionFunctions, tf)
+		}
+	}
+
+	return newFiniteAutomation(minimized, fa.inputs, minimized[class[fa.initialState]], acceptingStates, transitionFunctions)
+}
+
+// Function to check if two deterministic transducers have the same output behavior
+//   - both must accept the same inputs, and for every accepted input visit states
+//     with the same outputs and take transitions with the same outputs
+//   - transitions into states that cannot reach an accepting state count as undefined
+func AreTransducersEquivalent(t1 *FiniteAutomation, t2 *FiniteAutomation) (bool, error) {
+	for _, fa := range []*FiniteAutomation{t1, t2} {
+		if err := fa.checkUnguarded(); err != nil {
+			return false, err
+		}
+	}
+
+	type pair struct {
+		first  *State
+		second *State
+	}
+
+	live1, live2 := t1.liveStates(), t2.liveStates()
+	inputs := map[string]bool{}
+	for input := range t1.inputs {
+		inputs[input] = true
+	}
+	for input := range t2.inputs {
+		inputs[input] = true
+	}
+
+	start := pair{t1.initialState, t2.initialState}
+	seen := map[pair]bool{start: true}
+	queue := []pair{start}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+
+		if t1.acceptingStates[p.first] != t2.acceptingStates[p.second] || p.first.output != p.second.output {
+			return false, nil
+		}
+
+		for input := range inputs {
+			next1, ok1 := p.first.transition[input]
+			next2, ok2 := p.second.transition[input]
+			ok1 = ok1 && live1[next1]
+			ok2 = ok2 && live2[next2]
+			if ok1 != ok2 {
+				return false, nil
+			}
+
+			if !ok1 {
+				continue
+			}
+
+			if p.first.transitionOutput[input] != p.second.transitionOutput[input] {
+				return false, nil
+			}
+
+			next := pair{next1, next2}
+			if !seen[next] {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// feed runs the runes of the input from the given state and returns the state
+// reached and the concatenated transition outputs
+func (fa *FiniteAutomation) feed(state *State, input string) (*State, string, bool) {
+	var builder strings.Builder
+	for _, char := range input {
+		next, ok := state.transition[string(char)]
+		if !ok {
+			return nil, "", false
+		}
+
+		builder.WriteString(state.transitionOutput[string(char)])
+		state = next
+	}
+
+	return state, builder.String(), true
+}
+
+// liveStates returns the states from which an accepting state can be reached
+func (fa *FiniteAutomation) liveStates() map[*State]bool {
+	predecessors := map[*State][]*State{}
+	for state := range fa.states {
+		for _, next := range state.transition {
+			predecessors[next] = append(predecessors[next], state)
+		}
+		for _, transitionFunctions := range state.guardedTransitions {
+			for _, transitionFunction := range transitionFunctions {
+				predecessors[transitionFunction.transitionState] = append(predecessors[transitionFunction.transitionState], state)
+			}
+		}
+	}
+
+	live := map[*State]bool{}
+	queue := []*State{}
+	for state := range fa.acceptingStates {
+		live[state] = true
+		queue = append(queue, state)
+	}
+
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		for _, previous := range predecessors[state] {
+			if !live[previous] {
+				live[previous] = true
+				queue = append(queue, previous)
+			}
+		}
+	}
+
+	return live
+}
+
+// checkUnguarded returns an error if the automaton is not initialized or uses
+// guarded transitions, which cannot be resolved without a runtime context
+func (fa *FiniteAutomation) checkUnguarded() error {
+	if fa == nil || fa.states == nil || fa.initialState == nil || fa.acceptingStates == nil {
+		return errors.New("finite Automation has not been initialized")
+	}
+
+	for _, transitionFunction := range fa.transitionFunctions {
+		if transitionFunction.IsGuarded() {
+			return errors.New(fmt.Sprintln("Guarded transition not supported - from state: ", transitionFunction.GetCurrentState().GetOutput(), " on input: ", transitionFunction.GetInput()))
+		}
+	}
+
+	return nil
+}
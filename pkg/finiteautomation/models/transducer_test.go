@@ -0,0 +1,171 @@
+package models
+
+import "testing"
+
+// GetMockRedundantMealyFiniteAutomation returns a Mealy machine over {0, 1}
+// with three states that all output "x" on 0 and "y" on 1.
+func GetMockRedundantMealyFiniteAutomation() FiniteAutomation {
+	state1, state2, state3 := State{}, State{}, State{}
+	state1.Initialize("", map[string]*State{})
+	state2.Initialize("", map[string]*State{})
+	state3.Initialize("", map[string]*State{})
+
+	transitionFunctions := []TransitionFunction{}
+	for _, from := range []*State{&state1, &state2, &state3} {
+		tf1, tf2 := TransitionFunction{}, TransitionFunction{}
+		tf1.Initialize(from, "0", &state2)
+		tf1.SetOutput("x")
+		tf2.Initialize(from, "1", &state3)
+		tf2.SetOutput("y")
+		transitionFunctions = append(transitionFunctions, tf1, tf2)
+	}
+
+	finiteStates := map[*State]*State{&state1: &state1, &state2: &state2, &state3: &state3}
+	acceptingStates := []*State{&state1, &state2, &state3}
+	inputs := map[string]bool{"0": true, "1": true}
+
+	fa := FiniteAutomation{}
+	fa.InitializeFiniteAutomation(finiteStates, inputs, &state1, acceptingStates, transitionFunctions)
+
+	return fa
+}
+
+// TestCompose_NoError tests that the composed transducer translates every input
+// like the second transducer applied to the translation of the first one.
+func TestCompose_NoError(t *testing.T) {
+	t1 := GetMockMealyFiniteAutomation()
+
+	state := State{}
+	state.Initialize("", map[string]*State{})
+	transitionFunctions := []TransitionFunction{}
+	for input, output := range map[string]string{"e": "E", "c": "", "t": "T"} {
+		tf := TransitionFunction{}
+		tf.Initialize(&state, input, &state)
+		tf.SetOutput(output)
+		transitionFunctions = append(transitionFunctions, tf)
+	}
+	t2 := FiniteAutomation{}
+	t2.InitializeFiniteAutomation(map[*State]*State{&state: &state}, map[string]bool{"e": true, "c": true, "t": true}, &state, []*State{&state}, transitionFunctions)
+
+	composed, err := Compose(&t1, &t2)
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	for _, input := range []string{"", "0", "00110", "101"} {
+		intermediate, _ := t1.Translate(input)
+		expected, _ := t2.Translate(*intermediate)
+		result, err := composed.Translate(input)
+
+		if err != nil {
+			t.Errorf("Expected nil error, got %v", err)
+			continue
+		}
+
+		if *result != *expected {
+			t.Errorf("Expected %s, got %s for input %s", *expected, *result, input)
+		}
+	}
+}
+
+// TestMinimizeTransducer_MergesEquivalentStates tests that states with the same
+// output behavior are merged into one.
+func TestMinimizeTransducer_MergesEquivalentStates(t *testing.T) {
+	fa := GetMockRedundantMealyFiniteAutomation()
+
+	minimized, err := fa.MinimizeTransducer()
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	if len(minimized.states) != 1 {
+		t.Errorf("Expected %d states, got %d", 1, len(minimized.states))
+	}
+
+	equivalent, err := AreTransducersEquivalent(&fa, minimized)
+
+	if err != nil {
+		t.Errorf("Expected nil error, got %v", err)
+	}
+
+	if !equivalent {
+		t.Errorf("Expected minimized transducer to be equivalent")
+	}
+}
+
+// TestMinimizeTransducer_KeepsDistinctOutputs tests that states whose
+// transitions output different values are not merged.
+func TestMinimizeTransducer_KeepsDistinctOutputs(t *testing.T) {
+	fa := GetMockMealyFiniteAutomation()
+
+	minimized, err := fa.MinimizeTransducer()
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	if len(minimized.states) != 3 {
+		t.Errorf("Expected %d states, got %d", 3, len(minimized.states))
+	}
+}
+
+// TestMinimizeTransducer_DropsUnreachableStates tests that states unreachable
+// from the initial state are removed even if they can reach an accepting state.
+func TestMinimizeTransducer_DropsUnreachableStates(t *testing.T) {
+	state1, state2, state3 := State{}, State{}, State{}
+	state1.Initialize("x", map[string]*State{})
+	state2.Initialize("x", map[string]*State{})
+	state3.Initialize("y", map[string]*State{})
+
+	tf1 := TransitionFunction{}
+	tf1.Initialize(&state1, "0", &state2)
+	tf2 := TransitionFunction{}
+	tf2.Initialize(&state2, "0", &state1)
+	tf3 := TransitionFunction{}
+	tf3.Initialize(&state3, "0", &state1) // <-- state3 is unreachable
+	transitionFunctions := []TransitionFunction{tf1, tf2, tf3}
+
+	finiteStates := map[*State]*State{&state1: &state1, &state2: &state2, &state3: &state3}
+	fa := FiniteAutomation{}
+	fa.InitializeFiniteAutomation(finiteStates, map[string]bool{"0": true}, &state1, []*State{&state1, &state2}, transitionFunctions)
+
+	minimized, err := fa.MinimizeTransducer()
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	if len(minimized.states) != 1 {
+		t.Errorf("Expected %d states, got %d", 1, len(minimized.states))
+	}
+}
+
+// TestAreTransducersEquivalent_Different tests that transducers with different
+// outputs are reported as not equivalent.
+func TestAreTransducersEquivalent_Different(t *testing.T) {
+	t1 := GetMockMealyFiniteAutomation()
+	t2 := GetMockRedundantMealyFiniteAutomation()
+
+	equivalent, err := AreTransducersEquivalent(&t1, &t2)
+
+	if err != nil {
+		t.Errorf("Expected nil error, got %v", err)
+	}
+
+	if equivalent {
+		t.Errorf("Expected transducers not to be equivalent")
+	}
+}
+
+// TestMinimizeTransducer_ErrorGuarded tests that guarded transitions are rejected.
+func TestMinimizeTransducer_ErrorGuarded(t *testing.T) {
+	fa := GetMockGuardedFiniteAutomation()
+
+	minimized, err := fa.MinimizeTransducer()
+
+	if err == nil {
+		t.Errorf("Expected error for guarded transition, got nil")
+	}
+
+	if minimized != nil {
+		t.Errorf("Expected nil result")
+	}
+}
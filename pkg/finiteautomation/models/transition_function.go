@@ -2,13 +2,18 @@ package models
 
 // TransitionFunction represents a single transition function within a finite automaton.
 // It contains:
-// - currentState: the starting state.
-// - input: input string to trigger the transition
-// - transitionState: the state where it transitions to
+//   - currentState: the starting state.
+//   - input: input string to trigger the transition
+//   - transitionState: the state where it transitions to
+//   - output: optional output emitted when the transition is taken (Mealy output)
+//   - guard: optional predicate on the runtime context, the transition is only taken
+//     when the guard returns true
 type TransitionFunction struct {
 	currentState    *State
 	input           string
 	transitionState *State
+	output          string
+	guard           func(ctx Context) bool
 }
 
 func (t *TransitionFunction) Initialize(currentState *State, input string, transitionState *State) {
@@ -17,6 +22,19 @@ func (t *TransitionFunction) Initialize(currentState *State, input string, trans
 	t.transitionState = transitionState
 }
 
+// SetOutput attaches an output to the transition, emitted every time it is taken.
+func (t *TransitionFunction) SetOutput(output string) {
+	t.output = output
+}
+
+// SetGuard attaches a guard to the transition.
+//   - Several guarded transitions may share the same current state and input,
+//     they are evaluated in the order they are declared
+//   - A transition without guard on the same state and input acts as the fallback
+func (t *TransitionFunction) SetGuard(guard func(ctx Context) bool) {
+	t.guard = guard
+}
+
 func (t *TransitionFunction) GetCurrentState() *State {
 	return t.currentState
 }
@@ -28,3 +46,15 @@ func (t *TransitionFunction) GetInput() string {
 func (t *TransitionFunction) GetTransitionState() *State {
 	return t.transitionState
 }
+
+func (t *TransitionFunction) GetOutput() string {
+	return t.output
+}
+
+func (t *TransitionFunction) GetGuard() func(ctx Context) bool {
+	return t.guard
+}
+
+func (t *TransitionFunction) IsGuarded() bool {
+	return t.guard != nil
+}
@@ -0,0 +1,97 @@
+package models
+
+import "errors"
+
+// Function to enumerate the accepted words in shortlex order (by length, then
+// lexicographically over the sorted input symbols)
+//   - words longer than maxLen are not visited, at most limit words are visited
+//     when limit is positive
+//   - visit is called with every word, returning false stops the enumeration
+//   - branches that cannot reach an accepting state in the remaining length are
+//     pruned, so no time is spent on rejected strings
+//   - guarded transitions are not supported
+func (fa *FiniteAutomation) Words(maxLen int, limit int, visit func(word string) bool) error {
+	if err := fa.checkUnguarded(); err != nil {
+		return err
+	}
+
+	if maxLen < 0 {
+		return errors.New("invalid max length")
+	}
+
+	inputs := fa.sortedInputs()
+	reach := fa.acceptingWithin(maxLen)
+	count := 0
+	stopped := false
+
+	var walk func(state *State, word []string, remaining int)
+	walk = func(state *State, word []string, remaining int) {
+		if stopped {
+			return
+		}
+
+		if remaining == 0 {
+			if fa.acceptingStates[state] {
+				count++
+				if !visit(concat(word)) || (limit > 0 && count >= limit) {
+					stopped = true
+				}
+			}
+			return
+		}
+
+		for _, input := range inputs {
+			next, ok := state.transition[input]
+			if ok && reach[remaining-1][next] {
+				walk(next, append(word, input), remaining-1)
+			}
+		}
+	}
+
+	for length := 0; length <= maxLen && !stopped; length++ {
+		if reach[length][fa.initialState] {
+			walk(fa.initialState, []string{}, length)
+		}
+	}
+
+	return nil
+}
+
+// acceptingWithin returns, for every length up to maxLen, the states from which
+// an accepting state is reached by a word of exactly that length
+func (fa *FiniteAutomation) acceptingWithin(maxLen int) []map[*State]bool {
+	reach := make([]map[*State]bool, maxLen+1)
+	reach[0] = map[*State]bool{}
+	for state := range fa.acceptingStates {
+		reach[0][state] = true
+	}
+
+	for length := 1; length <= maxLen; length++ {
+		reach[length] = map[*State]bool{}
+		for state := range fa.states {
+			for _, next := range state.transition {
+				if reach[length-1][next] {
+					reach[length][state] = true
+					break
+				}
+			}
+		}
+	}
+
+	return reach
+}
+
+// concat joins the symbols of a word
+func concat(word []string) string {
+	length := 0
+	for _, symbol := range word {
+		length += len(symbol)
+	}
+
+	result := make([]byte, 0, length)
+	for _, symbol := range word {
+		result = append(result, symbol...)
+	}
+
+	return string(result)
+}
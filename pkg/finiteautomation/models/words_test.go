@@ -0,0 +1,75 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestWords_Shortlex tests that the accepted words are listed by length, then
+// lexicographically.
+func TestWords_Shortlex(t *testing.T) {
+	fa := GetMockModuloThreeFiniteAutomation()
+	moduloZero := GetMockModuloThreeFiniteAutomation()
+	moduloZero.acceptingStates = map[*State]bool{moduloZero.initialState: true}
+
+	words := []string{}
+	err := moduloZero.Words(4, 0, func(word string) bool {
+		words = append(words, word)
+		return true
+	})
+
+	if err != nil {
+		t.Errorf("Expected nil error, got %v", err)
+	}
+
+	expected := []string{"", "0", "00", "11", "000", "011", "110", "0000", "0011", "0110", "1001", "1100", "1111"}
+	if !reflect.DeepEqual(words, expected) {
+		t.Errorf("Expected %v, got %v", expected, words)
+	}
+
+	count := 0
+	fa.Words(3, 0, func(word string) bool {
+		count++
+		return true
+	})
+	if count != 15 {
+		t.Errorf("Expected %d words, got %d", 15, count)
+	}
+}
+
+// TestWords_Limit tests that the enumeration stops at the limit or when visit
+// returns false.
+func TestWords_Limit(t *testing.T) {
+	fa := GetMockFiniteAutomation()
+
+	words := []string{}
+	fa.Words(10, 3, func(word string) bool {
+		words = append(words, word)
+		return true
+	})
+
+	if !reflect.DeepEqual(words, []string{"", "01", "0101"}) {
+		t.Errorf("Expected %v, got %v", []string{"", "01", "0101"}, words)
+	}
+
+	words = []string{}
+	fa.Words(10, 0, func(word string) bool {
+		words = append(words, word)
+		return len(words) < 2
+	})
+
+	if len(words) != 2 {
+		t.Errorf("Expected %d words, got %d", 2, len(words))
+	}
+}
+
+// TestWords_ErrorGuarded tests that guarded transitions are rejected.
+func TestWords_ErrorGuarded(t *testing.T) {
+	fa := GetMockGuardedFiniteAutomation()
+
+	err := fa.Words(3, 0, func(word string) bool { return true })
+
+	if err == nil {
+		t.Errorf("Expected error for guarded transition, got nil")
+	}
+}